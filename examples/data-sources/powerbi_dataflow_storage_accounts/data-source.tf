@@ -0,0 +1,6 @@
+data "powerbi_dataflow_storage_accounts" "example" {
+}
+
+output "enabled_accounts" {
+  value = [for account in data.powerbi_dataflow_storage_accounts.example.accounts : account.name if account.is_enabled]
+}
@@ -0,0 +1,6 @@
+data "powerbi_deleted_workspaces" "example" {
+}
+
+output "deleted_workspaces" {
+  value = { for workspace in data.powerbi_deleted_workspaces.example.workspaces : workspace.name => workspace.id }
+}
@@ -0,0 +1,7 @@
+data "powerbi_workspace_contents" "example" {
+  workspace_name = "Sales"
+}
+
+output "refreshable_datasets" {
+  value = [for dataset in data.powerbi_workspace_contents.example.datasets : dataset.name if dataset.is_refreshable]
+}
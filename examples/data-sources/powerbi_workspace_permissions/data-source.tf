@@ -5,3 +5,13 @@ data "powerbi_workspace_permissions" "example_id" {
 output "permissions" {
   value = data.powerbi_workspace_permissions.example_id.permissions
 }
+
+data "powerbi_workspace_permissions" "example_admins" {
+  workspace_name = "Sales"
+  access_right   = "Admin"
+  use_admin_api  = true
+}
+
+output "admin_graph_ids" {
+  value = data.powerbi_workspace_permissions.example_admins.permissions[*].graph_id
+}
@@ -0,0 +1,8 @@
+data "powerbi_workspaces" "example" {
+  name_prefix              = "Sales - "
+  is_on_dedicated_capacity = true
+}
+
+output "workspace_ids" {
+  value = { for workspace in data.powerbi_workspaces.example.workspaces : workspace.name => workspace.id }
+}
@@ -0,0 +1,10 @@
+terraform {
+  required_providers {
+    powerbi = {
+      source = "WeAreRetail/powerbi"
+    }
+  }
+}
+
+provider "powerbi" {
+}
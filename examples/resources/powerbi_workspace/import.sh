@@ -0,0 +1,5 @@
+# A workspace can be imported by its ID
+terraform import powerbi_workspace.example 00000000-0000-0000-0000-000000000000
+
+# or by its name, which must match exactly one workspace, ignoring case
+terraform import powerbi_workspace.example "name:TF_WORKSPACE"
@@ -1,3 +1,4 @@
 resource "powerbi_workspace" "example" {
-  name = "TF_WORKSPACE"
+  name        = "TF_WORKSPACE"
+  description = "Workspace managed by Terraform"
 }
@@ -0,0 +1,2 @@
+# The dataflow storage assignment of a workspace is imported by the workspace ID
+terraform import powerbi_workspace_dataflow_storage.example 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,11 @@
+data "powerbi_dataflow_storage_accounts" "all" {
+}
+
+resource "powerbi_workspace" "example" {
+  name = "TF_DATAFLOWS"
+}
+
+resource "powerbi_workspace_dataflow_storage" "example" {
+  workspace_id        = powerbi_workspace.example.id
+  dataflow_storage_id = one([for account in data.powerbi_dataflow_storage_accounts.all.accounts : account.id if account.name == "datalake"])
+}
@@ -0,0 +1,2 @@
+# The members of a workspace are imported by the workspace ID
+terraform import powerbi_workspace_members.example 00000000-0000-0000-0000-000000000000
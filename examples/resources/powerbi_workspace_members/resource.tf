@@ -0,0 +1,23 @@
+resource "powerbi_workspace" "example" {
+  name = "Sales"
+}
+
+resource "powerbi_workspace_members" "example" {
+  workspace_id = powerbi_workspace.example.id
+
+  # The service principal Terraform authenticates with keeps its access.
+  protected_principals = ["8f2d1c6e-5b4a-4f3e-9d2c-1a0b9c8d7e6f"]
+
+  members = [
+    {
+      email_address  = "john.doe@contoso.com"
+      principal_type = "User"
+      access_right   = "Admin"
+    },
+    {
+      identifier     = "796131c3-8d85-44e1-bdfc-88ad8ba46520"
+      principal_type = "Group"
+      access_right   = "Viewer"
+    },
+  ]
+}
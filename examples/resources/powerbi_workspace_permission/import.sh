@@ -0,0 +1,3 @@
+# The access of a principal to a workspace is imported by the workspace ID and the principal, separated by a slash:
+# the email address of a user, or the object ID of a group or a service principal
+terraform import powerbi_workspace_permission.user 00000000-0000-0000-0000-000000000000/john.doe@contoso.com
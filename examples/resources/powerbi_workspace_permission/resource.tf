@@ -0,0 +1,30 @@
+resource "powerbi_workspace" "example" {
+  name = "Sales"
+}
+
+resource "powerbi_workspace_permission" "user" {
+  workspace_id   = powerbi_workspace.example.id
+  email_address  = "john.doe@contoso.com"
+  principal_type = "User"
+  access_right   = "Member"
+}
+
+resource "powerbi_workspace_permission" "group" {
+  workspace_id   = powerbi_workspace.example.id
+  identifier     = "796131c3-8d85-44e1-bdfc-88ad8ba46520"
+  principal_type = "Group"
+  access_right   = "Viewer"
+}
+
+# The principals may also be given by name, resolved through Microsoft Graph at plan time.
+resource "powerbi_workspace_permission" "sales" {
+  workspace_id       = powerbi_workspace.example.id
+  group_display_name = "Sales"
+  access_right       = "Contributor"
+}
+
+resource "powerbi_workspace_permission" "reporting" {
+  workspace_id   = powerbi_workspace.example.id
+  application_id = "0b9d8a3c-6f1e-4b2a-9c7d-5e4f3a2b1c0d"
+  access_right   = "Viewer"
+}
@@ -0,0 +1,8 @@
+data "powerbi_deleted_workspaces" "example" {
+}
+
+resource "powerbi_workspace_restore" "example" {
+  workspace_id        = one([for workspace in data.powerbi_deleted_workspaces.example.workspaces : workspace.id if workspace.name == "Sales"])
+  name                = "Sales (restored)"
+  admin_email_address = "admin@contoso.com"
+}
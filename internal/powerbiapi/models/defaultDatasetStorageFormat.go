@@ -1,9 +0,0 @@
-package models
-
-// DefaultDatasetStorageFormat represents the default dataset storage format in the group.
-type DefaultDatasetStorageFormat string
-
-const (
-	Large DefaultDatasetStorageFormat = "Large" // Large dataset storage format
-	Small DefaultDatasetStorageFormat = "Small" // Small dataset storage format
-)
@@ -1,9 +1,32 @@
 package provider
 
-import "terraform-provider-powerbi/internal/powerbiapi"
+import "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
 
 // getClient returns a new instance of the powerbiapi.Client with the specified base URL.
 // The base URL is used to establish the connection to the Power BI service.
-func getClient(baseUrl string) (*powerbiapi.Client, error) {
-	return powerbiapi.NewClient(baseUrl)
+// The Fabric base URL, when given, enables the Fabric API for a custom base URL or overrides its default URL.
+// The Graph base URL, when given, likewise enables Microsoft Graph or overrides its default URL.
+// When an access token is given, it is used instead of the Azure default credential chain. It is issued for the
+// Power BI API, so the Fabric API and Microsoft Graph are then only used when their base URL is given.
+func getClient(baseUrl string, fabricBaseUrl string, graphBaseUrl string, accessToken string) (*powerbiapi.Client, error) {
+	client, err := powerbiapi.NewClient(baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	if accessToken != "" {
+		client.Credentials = powerbiapi.NewStaticTokenCredential(accessToken)
+		client.FabricURL = ""
+		client.GraphURL = ""
+	}
+
+	if fabricBaseUrl != "" {
+		client.FabricURL = fabricBaseUrl
+	}
+
+	if graphBaseUrl != "" {
+		client.GraphURL = graphBaseUrl
+	}
+
+	return client, nil
 }
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+)
+
+var (
+	_ datasource.DataSource              = &DataflowStorageAccountsDataSource{} // Ensure that DataflowStorageAccountsDataSource implements the DataSource interface.
+	_ datasource.DataSourceWithConfigure = &DataflowStorageAccountsDataSource{} // Ensure that DataflowStorageAccountsDataSource implements the DataSourceWithConfigure interface.
+)
+
+// NewDataflowStorageAccountsDataSource is a function that creates a new instance of the DataflowStorageAccountsDataSource.
+func NewDataflowStorageAccountsDataSource() datasource.DataSource {
+	return &DataflowStorageAccountsDataSource{}
+}
+
+// DataflowStorageAccountsDataSource is a struct that represents the Power BI dataflow storage accounts data source.
+type DataflowStorageAccountsDataSource struct {
+	client *powerbiapi.Client
+}
+
+// Metadata is a method that sets the metadata for the DataflowStorageAccountsDataSource.
+func (d *DataflowStorageAccountsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataflow_storage_accounts"
+}
+
+// Schema is a method that sets the schema for the DataflowStorageAccountsDataSource.
+func (d *DataflowStorageAccountsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Power BI dataflow storage accounts data source, listing the Azure Data Lake Storage Gen2 accounts registered in the tenant that the user has access to",
+
+		Attributes: map[string]schema.Attribute{
+			"accounts": schema.ListNestedAttribute{
+				MarkdownDescription: "The dataflow storage accounts",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the dataflow storage account",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the dataflow storage account",
+							Computed:            true,
+						},
+						"is_enabled": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether workspaces can be assigned to the dataflow storage account",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure is a method that configures the DataflowStorageAccountsDataSource.
+func (d *DataflowStorageAccountsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerbiapi.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *powerbiapi.Client, got: %T. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read is a method that lists the dataflow storage accounts from the Power BI service.
+func (d *DataflowStorageAccountsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.DataflowStorageAccountsData
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accounts, err := d.client.GetDataflowStorageAccounts(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot retrieve dataflow storage accounts", err.Error())
+		return
+	}
+
+	data.Accounts = []models.DataflowStorageAccount{}
+	for _, account := range accounts.Value {
+		data.Accounts = append(data.Accounts, models.DataflowStorageAccount{
+			Id:        types.StringValue(account.Id),
+			Name:      types.StringValue(account.Name),
+			IsEnabled: types.BoolValue(account.IsEnabled),
+		})
+	}
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccDataflowStorageAccountsDataSource lists the dataflow storage accounts of the tenant.
+func TestAccDataflowStorageAccountsDataSource(t *testing.T) {
+	server := testAccServer(t)
+	lake := server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "lake", IsEnabled: true})
+	server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "archive"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_dataflow_storage_accounts" "test" {
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_dataflow_storage_accounts.test", "accounts.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_dataflow_storage_accounts.test", "accounts.0.id", lake.Id),
+					resource.TestCheckResourceAttr("data.powerbi_dataflow_storage_accounts.test", "accounts.0.name", "lake"),
+					resource.TestCheckResourceAttr("data.powerbi_dataflow_storage_accounts.test", "accounts.0.is_enabled", "true"),
+					resource.TestCheckResourceAttr("data.powerbi_dataflow_storage_accounts.test", "accounts.1.is_enabled", "false"),
+				),
+			},
+		},
+	})
+}
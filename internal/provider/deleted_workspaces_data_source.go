@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+var (
+	_ datasource.DataSource              = &DeletedWorkspacesDataSource{} // Ensure that DeletedWorkspacesDataSource implements the DataSource interface.
+	_ datasource.DataSourceWithConfigure = &DeletedWorkspacesDataSource{} // Ensure that DeletedWorkspacesDataSource implements the DataSourceWithConfigure interface.
+)
+
+// NewDeletedWorkspacesDataSource is a function that creates a new instance of the DeletedWorkspacesDataSource.
+func NewDeletedWorkspacesDataSource() datasource.DataSource {
+	return &DeletedWorkspacesDataSource{}
+}
+
+// DeletedWorkspacesDataSource is a struct that represents the Power BI deleted workspaces data source.
+type DeletedWorkspacesDataSource struct {
+	client *powerbiapi.Client
+}
+
+// Metadata is a method that sets the metadata for the DeletedWorkspacesDataSource.
+func (d *DeletedWorkspacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deleted_workspaces"
+}
+
+// Schema is a method that sets the schema for the DeletedWorkspacesDataSource.
+func (d *DeletedWorkspacesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Power BI deleted workspaces data source, listing the deleted workspaces of the organization an administrator can still restore. It uses the admin API: the provider must authenticate as a Fabric administrator",
+
+		Attributes: map[string]schema.Attribute{
+			"workspaces": schema.ListNestedAttribute{
+				MarkdownDescription: "The deleted workspaces",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the workspace",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the workspace",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the workspace, such as `Workspace`",
+							Computed:            true,
+						},
+						"capacity_id": schema.StringAttribute{
+							MarkdownDescription: "The id of the capacity the workspace was assigned to",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure is a method that configures the DeletedWorkspacesDataSource.
+func (d *DeletedWorkspacesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerbiapi.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *powerbiapi.Client, got: %T. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read is a method that lists the deleted workspaces from the Power BI service.
+func (d *DeletedWorkspacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.DeletedWorkspacesData
+
+	tflog.Debug(ctx, "Listing the deleted workspaces")
+	workspaces, err := listDeletedWorkspaces(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot list the deleted workspaces", err.Error())
+		return
+	}
+
+	data.Workspaces = []models.DeletedWorkspace{}
+	for _, workspace := range workspaces {
+		data.Workspaces = append(data.Workspaces, models.DeletedWorkspace{
+			Id:         types.StringValue(workspace.Id),
+			Name:       types.StringValue(workspace.Name),
+			Type:       optionalString(string(workspace.Type)),
+			CapacityId: optionalString(workspace.CapacityId),
+		})
+	}
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// listDeletedWorkspaces lists the workspaces of the organization in the Deleted state, with the admin API.
+func listDeletedWorkspaces(ctx context.Context, client *powerbiapi.Client) ([]pbiModels.Group, error) {
+	var workspaces []pbiModels.Group
+
+	pager := client.NewAdminGroupsPager(&powerbiapi.AdminGroupsPagerOptions{Filter: fmt.Sprintf("state eq '%s'", pbiModels.GroupStateDeleted)})
+	for pager.More() {
+		groups, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, groups.Value...)
+	}
+
+	return workspaces, nil
+}
+
+// findDeletedWorkspace returns the deleted workspace with the given id, or nil when no deleted workspace has it.
+func findDeletedWorkspace(ctx context.Context, client *powerbiapi.Client, workspaceId string) (*pbiModels.Group, error) {
+	workspaces, err := listDeletedWorkspaces(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, workspace := range workspaces {
+		if strings.EqualFold(workspace.Id, workspaceId) {
+			return &workspace, nil
+		}
+	}
+
+	return nil, nil
+}
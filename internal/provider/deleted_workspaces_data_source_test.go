@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccDeletedWorkspacesDataSource lists the deleted workspaces of the organization.
+func TestAccDeletedWorkspacesDataSource(t *testing.T) {
+	server := testAccServer(t)
+	server.PutGroup(fake.Group{Name: "tf-acc-active"})
+	deleted := server.PutDeletedGroup(fake.Group{Name: "tf-acc-deleted", CapacityId: "FEFC7A26-4758-41CD-9069-5E73B7E9DB0E"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_deleted_workspaces" "test" {
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_deleted_workspaces.test", "workspaces.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_deleted_workspaces.test", "workspaces.0.id", deleted.Id),
+					resource.TestCheckResourceAttr("data.powerbi_deleted_workspaces.test", "workspaces.0.name", "tf-acc-deleted"),
+					resource.TestCheckResourceAttr("data.powerbi_deleted_workspaces.test", "workspaces.0.type", "Workspace"),
+					resource.TestCheckResourceAttr("data.powerbi_deleted_workspaces.test", "workspaces.0.capacity_id", "FEFC7A26-4758-41CD-9069-5E73B7E9DB0E"),
+				),
+			},
+		},
+	})
+}
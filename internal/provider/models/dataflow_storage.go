@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// WorkspaceDataflowStorage is a struct that represents the assignment of a workspace to a dataflow storage account.
+type WorkspaceDataflowStorage struct {
+	Id                types.String `tfsdk:"id"`                  // The workspace id, the assignment is unique per workspace.
+	WorkspaceId       types.String `tfsdk:"workspace_id"`        // The workspace id.
+	DataflowStorageId types.String `tfsdk:"dataflow_storage_id"` // The dataflow storage account id.
+}
+
+// DataflowStorageAccountsData is a struct that represents the dataflow storage accounts data model.
+type DataflowStorageAccountsData struct {
+	Accounts []DataflowStorageAccount `tfsdk:"accounts"` // The dataflow storage accounts.
+}
+
+// DataflowStorageAccount is a struct that represents a dataflow storage account.
+type DataflowStorageAccount struct {
+	Id        types.String `tfsdk:"id"`         // The dataflow storage account id.
+	Name      types.String `tfsdk:"name"`       // The dataflow storage account name.
+	IsEnabled types.Bool   `tfsdk:"is_enabled"` // Whether workspaces can be assigned to the account.
+}
@@ -4,10 +4,80 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-// Workspace is a struct that represents the workspace data model.
+// Workspace is a struct that represents the workspace resource data model.
 type Workspace struct {
-	IsReadOnly            types.Bool   `tfsdk:"is_read_only"`
-	IsOnDedicatedCapacity types.Bool   `tfsdk:"is_on_dedicated_capacity"`
-	Id                    types.String `tfsdk:"id"`
-	Name                  types.String `tfsdk:"name"`
+	AdoptExisting               types.Bool             `tfsdk:"adopt_existing"`
+	IsReadOnly                  types.Bool             `tfsdk:"is_read_only"`
+	IsOnDedicatedCapacity       types.Bool             `tfsdk:"is_on_dedicated_capacity"`
+	CapacityId                  types.String           `tfsdk:"capacity_id"`
+	DefaultDatasetStorageFormat types.String           `tfsdk:"default_dataset_storage_format"`
+	Description                 types.String           `tfsdk:"description"`
+	ForceDestroy                types.Bool             `tfsdk:"force_destroy"`
+	Id                          types.String           `tfsdk:"id"`
+	LogAnalytics                *LogAnalyticsWorkspace `tfsdk:"log_analytics"`
+	Name                        types.String           `tfsdk:"name"`
+}
+
+// WorkspaceData is a struct that represents the workspace data source model,
+// the workspace attributes without the settings of the resource.
+type WorkspaceData struct {
+	IsReadOnly                  types.Bool             `tfsdk:"is_read_only"`
+	IsOnDedicatedCapacity       types.Bool             `tfsdk:"is_on_dedicated_capacity"`
+	CapacityId                  types.String           `tfsdk:"capacity_id"`
+	DefaultDatasetStorageFormat types.String           `tfsdk:"default_dataset_storage_format"`
+	Description                 types.String           `tfsdk:"description"`
+	Id                          types.String           `tfsdk:"id"`
+	LogAnalytics                *LogAnalyticsWorkspace `tfsdk:"log_analytics"`
+	Name                        types.String           `tfsdk:"name"`
+}
+
+// Data returns the attributes of the workspace exposed by the data source.
+func (w *Workspace) Data() WorkspaceData {
+	return WorkspaceData{
+		IsReadOnly:                  w.IsReadOnly,
+		IsOnDedicatedCapacity:       w.IsOnDedicatedCapacity,
+		CapacityId:                  w.CapacityId,
+		DefaultDatasetStorageFormat: w.DefaultDatasetStorageFormat,
+		Description:                 w.Description,
+		Id:                          w.Id,
+		LogAnalytics:                w.LogAnalytics,
+		Name:                        w.Name,
+	}
+}
+
+// LogAnalyticsWorkspace is a struct that represents the Azure Log Analytics workspace assigned to a workspace.
+type LogAnalyticsWorkspace struct {
+	SubscriptionId types.String `tfsdk:"subscription_id"`
+	ResourceGroup  types.String `tfsdk:"resource_group"`
+	ResourceName   types.String `tfsdk:"resource_name"`
+}
+
+// Equal tells whether two Log Analytics workspaces are the same, a nil workspace being equal to nil only.
+func (w *LogAnalyticsWorkspace) Equal(other *LogAnalyticsWorkspace) bool {
+	if w == nil || other == nil {
+		return w == other
+	}
+	return w.SubscriptionId.Equal(other.SubscriptionId) &&
+		w.ResourceGroup.Equal(other.ResourceGroup) &&
+		w.ResourceName.Equal(other.ResourceName)
+}
+
+// WorkspacesData is a struct that represents the workspaces data source model.
+type WorkspacesData struct {
+	NamePrefix            types.String       `tfsdk:"name_prefix"`              // Keep the workspaces whose name starts with the prefix.
+	NameContains          types.String       `tfsdk:"name_contains"`            // Keep the workspaces whose name contains the value.
+	CapacityId            types.String       `tfsdk:"capacity_id"`              // Keep the workspaces assigned to the capacity.
+	IsReadOnly            types.Bool         `tfsdk:"is_read_only"`             // Keep the workspaces with this read-only flag.
+	IsOnDedicatedCapacity types.Bool         `tfsdk:"is_on_dedicated_capacity"` // Keep the workspaces with this dedicated capacity flag.
+	Workspaces            []WorkspaceSummary `tfsdk:"workspaces"`               // The matching workspaces.
+}
+
+// WorkspaceSummary is a struct that represents a workspace listed by the workspaces data source.
+type WorkspaceSummary struct {
+	Id                          types.String `tfsdk:"id"`
+	Name                        types.String `tfsdk:"name"`
+	CapacityId                  types.String `tfsdk:"capacity_id"`
+	DefaultDatasetStorageFormat types.String `tfsdk:"default_dataset_storage_format"`
+	IsReadOnly                  types.Bool   `tfsdk:"is_read_only"`
+	IsOnDedicatedCapacity       types.Bool   `tfsdk:"is_on_dedicated_capacity"`
 }
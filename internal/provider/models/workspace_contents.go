@@ -0,0 +1,57 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// WorkspaceContentsData is a struct that represents the workspace contents data model.
+type WorkspaceContentsData struct {
+	WorkspaceId   types.String         `tfsdk:"workspace_id"`   // The workspace id.
+	WorkspaceName types.String         `tfsdk:"workspace_name"` // The workspace name.
+	Datasets      []WorkspaceDataset   `tfsdk:"datasets"`       // The datasets of the workspace.
+	Reports       []WorkspaceReport    `tfsdk:"reports"`        // The reports of the workspace.
+	Dashboards    []WorkspaceDashboard `tfsdk:"dashboards"`     // The dashboards of the workspace.
+	Dataflows     []WorkspaceDataflow  `tfsdk:"dataflows"`      // The dataflows of the workspace.
+	Datamarts     []WorkspaceDatamart  `tfsdk:"datamarts"`      // The datamarts of the workspace, nil when the Fabric API is not available.
+}
+
+// WorkspaceDataset is a struct that represents a dataset of a workspace.
+type WorkspaceDataset struct {
+	Id            types.String `tfsdk:"id"`             // The dataset id.
+	Name          types.String `tfsdk:"name"`           // The dataset name.
+	ConfiguredBy  types.String `tfsdk:"configured_by"`  // The dataset owner.
+	IsRefreshable types.Bool   `tfsdk:"is_refreshable"` // Whether the dataset can be refreshed.
+	WebUrl        types.String `tfsdk:"web_url"`        // The web URL of the dataset.
+}
+
+// WorkspaceReport is a struct that represents a report of a workspace.
+type WorkspaceReport struct {
+	Id         types.String `tfsdk:"id"`          // The report id.
+	Name       types.String `tfsdk:"name"`        // The report name.
+	DatasetId  types.String `tfsdk:"dataset_id"`  // The id of the dataset of the report.
+	ReportType types.String `tfsdk:"report_type"` // The report type, PowerBIReport or PaginatedReport.
+	WebUrl     types.String `tfsdk:"web_url"`     // The web URL of the report.
+}
+
+// WorkspaceDashboard is a struct that represents a dashboard of a workspace.
+type WorkspaceDashboard struct {
+	Id          types.String `tfsdk:"id"`           // The dashboard id.
+	DisplayName types.String `tfsdk:"display_name"` // The dashboard display name.
+	IsReadOnly  types.Bool   `tfsdk:"is_read_only"` // Whether the dashboard is read-only.
+	WebUrl      types.String `tfsdk:"web_url"`      // The web URL of the dashboard.
+}
+
+// WorkspaceDataflow is a struct that represents a dataflow of a workspace.
+type WorkspaceDataflow struct {
+	Id           types.String `tfsdk:"id"`            // The dataflow id.
+	Name         types.String `tfsdk:"name"`          // The dataflow name.
+	Description  types.String `tfsdk:"description"`   // The dataflow description.
+	ConfiguredBy types.String `tfsdk:"configured_by"` // The dataflow owner.
+}
+
+// WorkspaceDatamart is a struct that represents a datamart of a workspace.
+type WorkspaceDatamart struct {
+	Id          types.String `tfsdk:"id"`          // The datamart id.
+	Name        types.String `tfsdk:"name"`        // The datamart name.
+	Description types.String `tfsdk:"description"` // The datamart description.
+}
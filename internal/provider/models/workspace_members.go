@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// WorkspaceMembers is a struct that represents the complete list of the members of a workspace.
+type WorkspaceMembers struct {
+	Id                  types.String      `tfsdk:"id"`                   // The workspace id.
+	WorkspaceId         types.String      `tfsdk:"workspace_id"`         // The workspace id.
+	Members             []WorkspaceMember `tfsdk:"members"`              // The members of the workspace.
+	ProtectedPrincipals []types.String    `tfsdk:"protected_principals"` // The principals left untouched.
+}
+
+// WorkspaceMember is a struct that represents a member of a workspace.
+type WorkspaceMember struct {
+	EmailAddress  types.String `tfsdk:"email_address"`  // The email address of the user.
+	Identifier    types.String `tfsdk:"identifier"`     // The object id of the group or service principal.
+	PrincipalType types.String `tfsdk:"principal_type"` // The principal type: "User", "Group" or "App".
+	AccessRight   types.String `tfsdk:"access_right"`   // The access right: "Admin", "Member", "Contributor" or "Viewer".
+}
+
+// Principal returns the email address of the user, or the identifier of the group or service principal.
+func (m *WorkspaceMember) Principal() string {
+	if !m.EmailAddress.IsNull() {
+		return m.EmailAddress.ValueString()
+	}
+	return m.Identifier.ValueString()
+}
@@ -8,6 +8,10 @@ import (
 type WorkspacePermissionsData struct {
 	WorkspaceId   types.String          `tfsdk:"workspace_id"`   // The workspace id.
 	WorkspaceName types.String          `tfsdk:"workspace_name"` // The workspace name.
+	PrincipalType types.String          `tfsdk:"principal_type"` // Only list the permissions of this principal type.
+	AccessRight   types.String          `tfsdk:"access_right"`   // Only list the permissions with this access right.
+	UserType      types.String          `tfsdk:"user_type"`      // Only list the permissions of this user type.
+	UseAdminApi   types.Bool            `tfsdk:"use_admin_api"`  // Whether the permissions are read through the admin API.
 	Permissions   []WorkspacePermission `tfsdk:"permissions"`    // A list of permissions.
 }
 
@@ -15,7 +19,7 @@ type WorkspacePermissionsData struct {
 type WorkspacePermission struct {
 	DisplayName   types.String            `tfsdk:"display_name"`   // The display name of the user, group, or service principal.
 	EmailAddress  types.String            `tfsdk:"email_address"`  // The email address of the user.
-	GraphId       types.String            `tfsdk:"graph_id"`       // Identifier of the principal in Microsoft Graph. Only available for admin APIs.
+	GraphId       types.String            `tfsdk:"graph_id"`       // Identifier of the principal in Microsoft Graph. Only available through the admin API.
 	AccessRight   types.String            `tfsdk:"access_right"`   // The type of access right. Possible values include: "None", "Viewer", "Member", "Contributor", "Admin"
 	Identifier    types.String            `tfsdk:"identifier"`     // Identifier of the principal.
 	PrincipalType types.String            `tfsdk:"principal_type"` // The principal type. Possible values include: "User", "Group", "App"
@@ -28,3 +32,25 @@ type ServicePrincipalProfile struct {
 	DisplayName types.String `tfsdk:"display_name"` // The display name of the service principal.
 	Id          types.String `tfsdk:"id"`           // The identifier of the service principal.
 }
+
+// WorkspacePermissionAssignment is a struct that represents the access of a single principal to a workspace.
+type WorkspacePermissionAssignment struct {
+	Id            types.String `tfsdk:"id"`             // The workspace id and the principal, separated by a slash.
+	WorkspaceId   types.String `tfsdk:"workspace_id"`   // The workspace id.
+	EmailAddress  types.String `tfsdk:"email_address"`  // The email address of the user.
+	Identifier    types.String `tfsdk:"identifier"`     // The object id of the group or service principal.
+	PrincipalType types.String `tfsdk:"principal_type"` // The principal type: "User", "Group" or "App".
+	AccessRight   types.String `tfsdk:"access_right"`   // The access right: "Admin", "Member", "Contributor" or "Viewer".
+
+	UserPrincipalName types.String `tfsdk:"user_principal_name"` // The user principal name of the user, resolved through Microsoft Graph.
+	GroupDisplayName  types.String `tfsdk:"group_display_name"`  // The display name of the group, resolved through Microsoft Graph.
+	ApplicationId     types.String `tfsdk:"application_id"`      // The application id of the service principal, resolved through Microsoft Graph.
+}
+
+// Principal returns the email address of the user, or the identifier of the group or service principal.
+func (p *WorkspacePermissionAssignment) Principal() string {
+	if !p.EmailAddress.IsNull() {
+		return p.EmailAddress.ValueString()
+	}
+	return p.Identifier.ValueString()
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DeletedWorkspacesData is a struct that represents the deleted workspaces data source model.
+type DeletedWorkspacesData struct {
+	Workspaces []DeletedWorkspace `tfsdk:"workspaces"` // The deleted workspaces of the organization.
+}
+
+// DeletedWorkspace is a struct that represents a deleted workspace.
+type DeletedWorkspace struct {
+	Id         types.String `tfsdk:"id"`          // The workspace id.
+	Name       types.String `tfsdk:"name"`        // The workspace name.
+	Type       types.String `tfsdk:"type"`        // The workspace type.
+	CapacityId types.String `tfsdk:"capacity_id"` // The id of the capacity the workspace was assigned to.
+}
+
+// WorkspaceRestore is a struct that represents the restoration of a deleted workspace.
+type WorkspaceRestore struct {
+	Id                types.String `tfsdk:"id"`                  // The workspace id.
+	WorkspaceId       types.String `tfsdk:"workspace_id"`        // The id of the deleted workspace.
+	Name              types.String `tfsdk:"name"`                // The name of the restored workspace.
+	AdminEmailAddress types.String `tfsdk:"admin_email_address"` // The email address of the admin of the restored workspace.
+}
@@ -3,16 +3,23 @@ package provider
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"terraform-provider-powerbi/internal/powerbiapi"
-	pbiModels "terraform-provider-powerbi/internal/powerbiapi/models"
-	"terraform-provider-powerbi/internal/provider/models"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
 )
 
 var _ resource.Resource = &PipelineResource{}                // Ensure that PipelineResource implements the Resource interface.
@@ -65,7 +72,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 
 	tflog.Debug(ctx, fmt.Sprintf("Creating pipeline with name: %s", config.DisplayName.ValueString()))
 
-	pipeline, err = r.client.CreatePipeline(config.DisplayName.ValueString(), config.Description.ValueString())
+	pipeline, err = r.client.CreatePipeline(ctx, config.DisplayName.ValueString(), config.Description.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot create pipeline with name %s", config.DisplayName.ValueString()), err.Error())
@@ -75,7 +82,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 
 	// As the creation of the pipeline doesn't yield a full json object describing the pipeline
 	// We must get the pipeline full json object by using the GetPipeline method.
-	pipeline, err = r.client.GetPipeline(pipeline.Id)
+	pipeline, err = r.client.GetPipeline(ctx, pipeline.Id)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve pipeline with Id %s", state.Id.ValueString()), err.Error())
 		return
@@ -83,7 +90,11 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	tflog.Debug(ctx, "Populate the response with the pipeline data")
 	state.Id = types.StringValue(pipeline.Id)
 	state.DisplayName = types.StringValue(pipeline.DisplayName)
-	state.Description = types.StringValue(pipeline.Description)
+	if pipeline.Description != "" {
+		state.Description = types.StringValue(pipeline.Description)
+	} else {
+		state.Description = types.StringNull()
+	}
 	var stages []models.PipelineStage
 	for _, stage := range pipeline.Stages {
 		var pipelineStage models.PipelineStage
@@ -137,7 +148,7 @@ func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Deleting pipeline with name: %s", state.DisplayName.ValueString()))
-	err = r.client.DeletePipeline(state.Id.ValueString())
+	err = r.client.DeletePipeline(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot delete pipeline with Id %s", state.Id.ValueString()), err.Error())
 		return
@@ -146,9 +157,9 @@ func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	tflog.Debug(ctx, "Pipeline deleted successfully")
 }
 
-// ImportState implements resource.ResourceWithImportState.
-func (r *PipelineResource) ImportState(_ context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	panic("unimplemented")
+// ImportState imports an existing pipeline by its ID.
+func (r *PipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
 // Metadata sets the metadata for the PipelineResource.
@@ -169,7 +180,13 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Reading pipeline with name: %s", state.DisplayName.ValueString()))
-	pipeline, err = r.client.GetPipeline(state.Id.ValueString())
+	pipeline, err = r.client.GetPipeline(ctx, state.Id.ValueString())
+	if powerbiapi.IsNotFound(err) {
+		// The pipeline was deleted outside of Terraform, removing it from the state plans its creation again.
+		tflog.Warn(ctx, fmt.Sprintf("Pipeline with Id %s not found, removing it from the state", state.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve pipeline with Id %s", state.Id.ValueString()), err.Error())
 		return
@@ -177,7 +194,11 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	state.Id = types.StringValue(pipeline.Id)
 	state.DisplayName = types.StringValue(pipeline.DisplayName)
-	state.Description = types.StringValue(pipeline.Description)
+	if pipeline.Description != "" {
+		state.Description = types.StringValue(pipeline.Description)
+	} else {
+		state.Description = types.StringNull()
+	}
 	var stages []models.PipelineStage
 	for _, stage := range pipeline.Stages {
 		var pipelineStage models.PipelineStage
@@ -226,22 +247,35 @@ func (r *PipelineResource) Schema(_ context.Context, req resource.SchemaRequest,
 		MarkdownDescription: "Power BI pipeline resource",
 		Attributes: map[string]schema.Attribute{
 			"display_name": schema.StringAttribute{
-				MarkdownDescription: "The name of the pipeline",
+				MarkdownDescription: "The name of the pipeline, at most 256 characters",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 256),
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The id of the pipeline",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "The description of the pipeline",
+				MarkdownDescription: "The description of the pipeline, at most 1024 characters",
 				Computed:            false,
 				Required:            false,
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(1024),
+				},
 			},
 			"stages": schema.ListAttribute{
 				MarkdownDescription: "The stages of the pipeline",
 				Computed:            true,
+				// Updating the pipeline does not change the workspaces assigned to its stages.
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 				ElementType: types.ObjectType{
 					AttrTypes: map[string]attr.Type{
 						"order":          types.Int64Type,
@@ -277,7 +311,7 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 	tflog.Info(ctx, fmt.Sprintf("Update pipeline Request: %s, %s", updateRequest.DisplayName, updateRequest.Description))
 
 	tflog.Debug(ctx, fmt.Sprintf("Updating pipeline with name: %s", state.DisplayName.ValueString()))
-	_, err = r.client.UpdatePipeline(state.Id.ValueString(), *updateRequest)
+	_, err = r.client.UpdatePipeline(ctx, state.Id.ValueString(), *updateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot update pipeline with Id %s %s", state.Id.ValueString(), err), err.Error())
 		return
@@ -287,7 +321,7 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 
 	tflog.Debug(ctx, "Populate the response with the pipeline data")
 	tflog.Debug(ctx, fmt.Sprintf("Reading pipeline with name: %s", plan.DisplayName.ValueString()))
-	pipeline, err = r.client.GetPipeline(state.Id.ValueString())
+	pipeline, err = r.client.GetPipeline(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve pipeline with Id %s", state.Id.ValueString()), err.Error())
 		return
@@ -295,7 +329,11 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 
 	state.Id = types.StringValue(pipeline.Id)
 	state.DisplayName = types.StringValue(pipeline.DisplayName)
-	state.Description = types.StringValue(pipeline.Description)
+	if pipeline.Description != "" {
+		state.Description = types.StringValue(pipeline.Description)
+	} else {
+		state.Description = types.StringNull()
+	}
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
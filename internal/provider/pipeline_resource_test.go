@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccPipelineResource creates, imports, updates and destroys a deployment pipeline,
+// and repairs a description changed in the portal.
+func TestAccPipelineResource(t *testing.T) {
+	server := testAccServer(t)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPipelineDestroy(server),
+		Steps: []resource.TestStep{
+			// Plan-time validation
+			{
+				Config:      testAccPipelineResourceConfig(server, "tf-acc-pipeline", strings.Repeat("x", 1025)),
+				ExpectError: regexp.MustCompile(`string length must be at most 1024`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccPipelineResourceConfig(server, "tf-acc-pipeline", "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_pipeline.test", "display_name", "tf-acc-pipeline"),
+					resource.TestCheckResourceAttr("powerbi_pipeline.test", "description", "first"),
+					resource.TestCheckResourceAttr("powerbi_pipeline.test", "stages.#", "3"),
+					resource.TestCheckResourceAttr("powerbi_pipeline.test", "stages.0.order", "0"),
+					resource.TestCheckNoResourceAttr("powerbi_pipeline.test", "stages.0.workspace_id"),
+					resource.TestCheckResourceAttrSet("powerbi_pipeline.test", "id"),
+					testAccCaptureId("powerbi_pipeline.test", &id),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerbi_pipeline.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPipelineResourceConfig(server, "tf-acc-pipeline-renamed", "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_pipeline.test", plancheck.ResourceActionUpdate),
+						testAccExpectKnownValues("powerbi_pipeline.test", "id", "stages"),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_pipeline.test", "display_name", "tf-acc-pipeline-renamed"),
+					resource.TestCheckResourceAttr("powerbi_pipeline.test", "description", "second"),
+					resource.TestCheckResourceAttrPtr("powerbi_pipeline.test", "id", &id),
+				),
+			},
+			// Drift testing: the description is changed outside of Terraform, the next apply restores it.
+			{
+				PreConfig: func() {
+					pipeline, _ := server.Pipeline(id)
+					pipeline.Description = "changed in the portal"
+					server.PutPipeline(pipeline)
+				},
+				Config: testAccPipelineResourceConfig(server, "tf-acc-pipeline-renamed", "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_pipeline.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_pipeline.test", "description", "second"),
+					testAccCheckPipelineDescription(server, &id, "second"),
+				),
+			},
+			// Drift testing: the pipeline is deleted outside of Terraform, the next apply creates it again.
+			{
+				PreConfig: func() {
+					server.RemovePipeline(id)
+				},
+				Config: testAccPipelineResourceConfig(server, "tf-acc-pipeline-renamed", "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_pipeline.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_pipeline.test", "display_name", "tf-acc-pipeline-renamed"),
+					testAccCaptureId("powerbi_pipeline.test", &id),
+					testAccCheckPipelineDescription(server, &id, "second"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// TestAccPipelineResource_NoDescription checks that a pipeline without description stays consistent across applies.
+func TestAccPipelineResource_NoDescription(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPipelineDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "powerbi_pipeline" "test" {
+  display_name = "tf-acc-pipeline"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("powerbi_pipeline.test", "description"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPipelineResourceConfig(server *fake.Server, displayName string, description string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_pipeline" "test" {
+  display_name = %q
+  description  = %q
+}
+`, displayName, description)
+}
+
+// testAccCheckPipelineDescription checks the description of the pipeline on the service side.
+func testAccCheckPipelineDescription(server *fake.Server, id *string, description string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		pipeline, ok := server.Pipeline(*id)
+		if !ok {
+			return fmt.Errorf("pipeline %s does not exist", *id)
+		}
+		if pipeline.Description != description {
+			return fmt.Errorf("pipeline %s has description %q, expected %q", *id, pipeline.Description, description)
+		}
+		return nil
+	}
+}
+
+// testAccCheckPipelineDestroy checks that every pipeline in the state was deleted from the service.
+func testAccCheckPipelineDestroy(server *fake.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "powerbi_pipeline" {
+				continue
+			}
+			if _, ok := server.Pipeline(rs.Primary.ID); ok {
+				return fmt.Errorf("pipeline %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
@@ -26,7 +26,10 @@ type PowerBIProvider struct {
 
 // PowerBIProviderModel describes the provider data model.
 type PowerBIProviderModel struct {
-	BaseURL types.String `tfsdk:"base_url"`
+	BaseURL       types.String `tfsdk:"base_url"`
+	FabricBaseURL types.String `tfsdk:"fabric_base_url"`
+	GraphBaseURL  types.String `tfsdk:"graph_base_url"`
+	AccessToken   types.String `tfsdk:"access_token"`
 }
 
 func (p *PowerBIProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -42,6 +45,22 @@ func (p *PowerBIProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Description:         "The base url for the Power BI API. Default to \"https://api.powerbi.com\"",
 				Optional:            true,
 			},
+			"fabric_base_url": schema.StringAttribute{
+				MarkdownDescription: "The base url for the Fabric API, which manages the workspace descriptions. Default to \"https://api.fabric.microsoft.com\" when `base_url` is not set, the Fabric API is not used otherwise.",
+				Description:         "The base url for the Fabric API, which manages the workspace descriptions. Default to \"https://api.fabric.microsoft.com\" when base_url is not set, the Fabric API is not used otherwise.",
+				Optional:            true,
+			},
+			"graph_base_url": schema.StringAttribute{
+				MarkdownDescription: "The base url for Microsoft Graph, which resolves the principals given by user principal name, group name or application id. Default to \"https://graph.microsoft.com\" when `base_url` is not set, Microsoft Graph is not used otherwise.",
+				Description:         "The base url for Microsoft Graph, which resolves the principals given by user principal name, group name or application id. Default to \"https://graph.microsoft.com\" when base_url is not set, Microsoft Graph is not used otherwise.",
+				Optional:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "A bearer token used as-is to call the Power BI API, instead of the Azure default credential chain. It is never refreshed, so it is mostly useful for tests and short-lived automation. As it is issued for the Power BI API, the Fabric API and Microsoft Graph are only called with it when `fabric_base_url` and `graph_base_url` are set.",
+				Description:         "A bearer token used as-is to call the Power BI API, instead of the Azure default credential chain. It is never refreshed, so it is mostly useful for tests and short-lived automation. As it is issued for the Power BI API, the Fabric API and Microsoft Graph are only called with it when fabric_base_url and graph_base_url are set.",
+				Optional:            true,
+				Sensitive:           true,
+			},
 		},
 	}
 }
@@ -56,7 +75,7 @@ func (p *PowerBIProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 
 	// Create a new instance of the powerbiapi.Client with the specified base URL.
-	client, err := getClient(data.BaseURL.ValueString())
+	client, err := getClient(data.BaseURL.ValueString(), data.FabricBaseURL.ValueString(), data.GraphBaseURL.ValueString(), data.AccessToken.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("failed to create client", err.Error())
 		return
@@ -69,6 +88,10 @@ func (p *PowerBIProvider) Resources(ctx context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewWorkspaceResource,
 		NewPipelineResource,
+		NewWorkspaceDataflowStorageResource,
+		NewWorkspaceRestoreResource,
+		NewWorkspacePermissionResource,
+		NewWorkspaceMembersResource,
 	}
 }
 
@@ -76,6 +99,10 @@ func (p *PowerBIProvider) DataSources(ctx context.Context) []func() datasource.D
 	return []func() datasource.DataSource{
 		NewWorkspaceDataSource,
 		NewWorkspacePermissionsDataSource,
+		NewDataflowStorageAccountsDataSource,
+		NewWorkspaceContentsDataSource,
+		NewWorkspacesDataSource,
+		NewDeletedWorkspacesDataSource,
 	}
 }
 
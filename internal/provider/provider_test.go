@@ -1,25 +1,104 @@
-// // Copyright (c) HashiCorp, Inc.
-// // SPDX-License-Identifier: MPL-2.0
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
 
 package provider
 
-// import (
-// 	"testing"
-
-// 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-// 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-// )
-
-// // testAccProtoV6ProviderFactories are used to instantiate a provider during
-// // acceptance testing. The factory function will be invoked for every Terraform
-// // CLI command executed to create a provider server to which the CLI can
-// // reattach.
-// // var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-// // 	"powerbi": providerserver.NewProtocol6WithError(New("test")()),
-// // }
-
-// // func testAccPreCheck(t *testing.T) {
-// // 	// You can add code here to run prior to any test case execution, for example assertions
-// // 	// about the appropriate environment variables being set are common to see in a pre-check
-// // 	// function.
-// // }
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"powerbi": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+func testAccPreCheck(t *testing.T) {
+	// You can add code here to run prior to any test case execution, for example assertions
+	// about the appropriate environment variables being set are common to see in a pre-check
+	// function.
+}
+
+// testAccServer starts a fake Power BI service for the duration of the test.
+func testAccServer(t *testing.T) *fake.Server {
+	server := fake.NewTestServer(t)
+	return server
+}
+
+// testAccProviderConfig returns the provider block pointing at the fake Power BI service, which also serves the Fabric API
+// and Microsoft Graph.
+func testAccProviderConfig(server *fake.Server) string {
+	return fmt.Sprintf(`
+provider "powerbi" {
+  base_url        = %q
+  fabric_base_url = %q
+  graph_base_url  = %q
+  access_token    = %q
+}
+`, server.URL, server.URL, server.URL, fake.Token)
+}
+
+// testAccProviderConfigWithoutFabric returns the configuration of a provider using the fake server
+// for the Power BI API only, the Fabric API being unavailable as for any custom base_url.
+func testAccProviderConfigWithoutFabric(server *fake.Server) string {
+	return fmt.Sprintf(`
+provider "powerbi" {
+  base_url     = %q
+  access_token = %q
+}
+`, server.URL, fake.Token)
+}
+
+// testAccCaptureId stores the id of a resource in the state into id, so later steps can refer to it.
+func testAccCaptureId(name string, id *string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccExpectKnownValues is a plan check failing when one of the attributes of a resource is unknown in the plan.
+func testAccExpectKnownValues(address string, attributes ...string) plancheck.PlanCheck {
+	return expectKnownValues{address: address, attributes: attributes}
+}
+
+// expectKnownValues is the plan check returned by testAccExpectKnownValues.
+type expectKnownValues struct {
+	address    string
+	attributes []string
+}
+
+// CheckPlan implements the plancheck.PlanCheck interface.
+func (e expectKnownValues) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Address != e.address {
+			continue
+		}
+
+		unknown, _ := rc.Change.AfterUnknown.(map[string]interface{})
+		for _, attribute := range e.attributes {
+			if unknown[attribute] == true {
+				resp.Error = fmt.Errorf("attribute %s of %s is unknown in the plan", attribute, e.address)
+				return
+			}
+		}
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s not found in the plan", e.address)
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// testAccPrefix starts the names of the workspaces and pipelines created by the acceptance tests,
+// so the sweepers can tell them from the other content of a sandbox tenant.
+const testAccPrefix = "tf-acc"
+
+// sweepPageSize is the number of workspaces listed per request by the sweepers.
+const sweepPageSize = 100
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("powerbi_pipeline", &resource.Sweeper{
+		Name: "powerbi_pipeline",
+		F: func(region string) error {
+			client, err := sweepClient(region)
+			if err != nil {
+				return err
+			}
+			return sweepPipelines(context.Background(), client, testAccPrefix)
+		},
+	})
+
+	resource.AddTestSweepers("powerbi_workspace", &resource.Sweeper{
+		Name:         "powerbi_workspace",
+		Dependencies: []string{"powerbi_pipeline"},
+		F: func(region string) error {
+			client, err := sweepClient(region)
+			if err != nil {
+				return err
+			}
+			return sweepWorkspaces(context.Background(), client, testAccPrefix)
+		},
+	})
+}
+
+// sweepClient returns the client used by the sweepers, run with "go test ./internal/provider -sweep=<region>".
+// Power BI has no regions: "fake" sweeps an in-process fake service, which checks the sweepers run, and any
+// other value targets POWERBI_BASE_URL, the Power BI service by default. The client authenticates with
+// POWERBI_ACCESS_TOKEN when it is set, with the Azure default credential chain otherwise.
+func sweepClient(region string) (*powerbiapi.Client, error) {
+	baseUrl, accessToken := os.Getenv("POWERBI_BASE_URL"), os.Getenv("POWERBI_ACCESS_TOKEN")
+	if region == "fake" {
+		// The sweepers run once per process, the server is released at exit.
+		baseUrl, accessToken = fake.NewServer().URL, fake.Token
+	}
+
+	client, err := getClient(baseUrl, "", "", accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the sweeper client: %v", err)
+	}
+	return client, nil
+}
+
+// hasSweepPrefix tells whether a name was given by the acceptance tests.
+func hasSweepPrefix(name string, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix))
+}
+
+// sweepPipelines unassigns the workspaces of the pipelines whose name starts with prefix, then deletes the pipelines.
+// It carries on after a failure, so one stuck pipeline does not keep the others around.
+func sweepPipelines(ctx context.Context, client *powerbiapi.Client, prefix string) error {
+	pipelines, err := client.GetPipelines(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pipelines: %v", err)
+	}
+
+	var errs []error
+	for _, pipeline := range pipelines.Value {
+		if !hasSweepPrefix(pipeline.DisplayName, prefix) {
+			continue
+		}
+
+		log.Printf("[INFO] Sweeping pipeline %s (%s)", pipeline.DisplayName, pipeline.Id)
+
+		for _, stage := range pipeline.Stages {
+			if stage.WorkspaceId == "" {
+				continue
+			}
+			if err := client.UnassignWorkspace(ctx, pipeline.Id, stage.Order); err != nil {
+				errs = append(errs, fmt.Errorf("pipeline %s, stage %d: %v", pipeline.Id, stage.Order, err))
+			}
+		}
+
+		if err := client.DeletePipeline(ctx, pipeline.Id); err != nil {
+			errs = append(errs, fmt.Errorf("pipeline %s: %v", pipeline.Id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sweepWorkspaces deletes the workspaces whose name starts with prefix. The workspaces still assigned to a
+// pipeline stage, which cannot be deleted, are unassigned first, whatever the name of the pipeline.
+func sweepWorkspaces(ctx context.Context, client *powerbiapi.Client, prefix string) error {
+	filter := fmt.Sprintf("startswith(name,'%s')", strings.ReplaceAll(prefix, "'", "''"))
+
+	var workspaces []string
+	names := map[string]string{}
+	pager := client.NewGroupsPager(&powerbiapi.GroupsPagerOptions{Filter: filter, PageSize: sweepPageSize})
+	for pager.More() {
+		groups, err := pager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list workspaces: %v", err)
+		}
+
+		for _, group := range groups.Value {
+			// The filter is applied by the service, check again in case it is ignored.
+			if hasSweepPrefix(group.Name, prefix) {
+				workspaces = append(workspaces, group.Id)
+				names[group.Id] = group.Name
+			}
+		}
+	}
+
+	if len(workspaces) == 0 {
+		return nil
+	}
+
+	pipelines, err := client.GetPipelines(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pipelines: %v", err)
+	}
+
+	var errs []error
+	for _, pipeline := range pipelines.Value {
+		for _, stage := range pipeline.Stages {
+			if _, swept := names[stage.WorkspaceId]; !swept {
+				continue
+			}
+			if err := client.UnassignWorkspace(ctx, pipeline.Id, stage.Order); err != nil {
+				errs = append(errs, fmt.Errorf("pipeline %s, stage %d: %v", pipeline.Id, stage.Order, err))
+			}
+		}
+	}
+
+	for _, id := range workspaces {
+		log.Printf("[INFO] Sweeping workspace %s (%s)", names[id], id)
+
+		if err := client.DeleteGroup(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("workspace %s: %v", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// TestSweepers checks the sweepers against the fake service: the test workspaces and pipelines are deleted,
+// stage assignments included, and the other content of the tenant is kept.
+func TestSweepers(t *testing.T) {
+	server := testAccServer(t)
+
+	kept := server.PutGroup(fake.Group{Name: "Finance"})
+	dev := server.PutGroup(fake.Group{Name: "tf-acc-dev"})
+	prd := server.PutGroup(fake.Group{Name: "TF-ACC-prd"})
+	for i := 0; i < sweepPageSize; i++ {
+		server.PutGroup(fake.Group{Name: fmt.Sprintf("tf-acc-leak-%03d", i)})
+	}
+	leaked := server.PutPipeline(fake.Pipeline{
+		DisplayName: "tf-acc-pipeline",
+		Stages:      []fake.PipelineStage{{Order: 0, WorkspaceId: dev.Id}},
+	})
+	keptPipeline := server.PutPipeline(fake.Pipeline{
+		DisplayName: "Finance pipeline",
+		Stages:      []fake.PipelineStage{{Order: 0, WorkspaceId: kept.Id}, {Order: 2, WorkspaceId: prd.Id}},
+	})
+
+	client, err := getClient(server.URL, "", "", fake.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := sweepPipelines(ctx, client, testAccPrefix); err != nil {
+		t.Fatalf("pipeline sweeper: %v", err)
+	}
+	if err := sweepWorkspaces(ctx, client, testAccPrefix); err != nil {
+		t.Fatalf("workspace sweeper: %v", err)
+	}
+
+	if _, ok := server.Pipeline(leaked.Id); ok {
+		t.Errorf("pipeline %s was not swept", leaked.Id)
+	}
+	if groups := server.Groups(); len(groups) != 1 || groups[0].Id != kept.Id {
+		t.Errorf("expected only workspace %s to be kept, got %v", kept.Id, groups)
+	}
+
+	pipeline, ok := server.Pipeline(keptPipeline.Id)
+	if !ok {
+		t.Fatalf("pipeline %s was swept", keptPipeline.Id)
+	}
+	if pipeline.Stages[0].WorkspaceId != kept.Id || pipeline.Stages[2].WorkspaceId != "" {
+		t.Errorf("unexpected stages of the kept pipeline: %v", pipeline.Stages)
+	}
+}
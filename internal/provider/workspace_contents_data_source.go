@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+var (
+	_ datasource.DataSource                   = &WorkspaceContentsDataSource{} // Ensure that WorkspaceContentsDataSource implements the DataSource interface.
+	_ datasource.DataSourceWithValidateConfig = &WorkspaceContentsDataSource{} // Ensure that WorkspaceContentsDataSource implements the DataSourceWithValidateConfig interface.
+	_ datasource.DataSourceWithConfigure      = &WorkspaceContentsDataSource{} // Ensure that WorkspaceContentsDataSource implements the DataSourceWithConfigure interface.
+)
+
+// NewWorkspaceContentsDataSource is a function that creates a new instance of the WorkspaceContentsDataSource.
+func NewWorkspaceContentsDataSource() datasource.DataSource {
+	return &WorkspaceContentsDataSource{}
+}
+
+// WorkspaceContentsDataSource is a struct that represents the Power BI workspace contents data source.
+type WorkspaceContentsDataSource struct {
+	client *powerbiapi.Client
+}
+
+// Metadata is a method that sets the metadata for the WorkspaceContentsDataSource.
+func (d *WorkspaceContentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspace_contents"
+}
+
+// Schema is a method that sets the schema for the WorkspaceContentsDataSource.
+func (d *WorkspaceContentsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Power BI workspace contents data source, listing the datasets, reports, dashboards, dataflows and datamarts of a workspace",
+
+		Attributes: map[string]schema.Attribute{
+			"workspace_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the workspace",
+				Optional:            true,
+				Computed:            true,
+			},
+			"workspace_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the workspace",
+				Optional:            true,
+				Computed:            true,
+			},
+			"datasets": schema.ListNestedAttribute{
+				MarkdownDescription: "The datasets of the workspace",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the dataset",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the dataset",
+							Computed:            true,
+						},
+						"configured_by": schema.StringAttribute{
+							MarkdownDescription: "The owner of the dataset",
+							Computed:            true,
+						},
+						"is_refreshable": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether the dataset can be refreshed",
+							Computed:            true,
+						},
+						"web_url": schema.StringAttribute{
+							MarkdownDescription: "The web URL of the dataset",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"reports": schema.ListNestedAttribute{
+				MarkdownDescription: "The reports of the workspace",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the report",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the report",
+							Computed:            true,
+						},
+						"dataset_id": schema.StringAttribute{
+							MarkdownDescription: "The id of the dataset of the report",
+							Computed:            true,
+						},
+						"report_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the report, `PowerBIReport` or `PaginatedReport`",
+							Computed:            true,
+						},
+						"web_url": schema.StringAttribute{
+							MarkdownDescription: "The web URL of the report",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"dashboards": schema.ListNestedAttribute{
+				MarkdownDescription: "The dashboards of the workspace",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the dashboard",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the dashboard",
+							Computed:            true,
+						},
+						"is_read_only": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether the dashboard is read-only",
+							Computed:            true,
+						},
+						"web_url": schema.StringAttribute{
+							MarkdownDescription: "The web URL of the dashboard",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"dataflows": schema.ListNestedAttribute{
+				MarkdownDescription: "The dataflows of the workspace",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the dataflow",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the dataflow",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the dataflow",
+							Computed:            true,
+						},
+						"configured_by": schema.StringAttribute{
+							MarkdownDescription: "The owner of the dataflow",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"datamarts": schema.ListNestedAttribute{
+				MarkdownDescription: "The datamarts of the workspace, listed by the Fabric API. It is null when the Fabric API is not available or denies access, with a warning in the latter case",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the datamart",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the datamart",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the datamart",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the configuration for the WorkspaceContentsDataSource.
+// Exactly one of 'workspace_id' or 'workspace_name' must be set.
+func (d *WorkspaceContentsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data models.WorkspaceContentsData
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bothNull := data.WorkspaceName.IsNull() && data.WorkspaceId.IsNull()
+	bothSet := !data.WorkspaceName.IsNull() && !data.WorkspaceId.IsNull()
+
+	if bothNull || bothSet {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workspace_id"),
+			"Invalid attribute configuration",
+			"one of 'workspace_id' or 'workspace_name' must be set",
+		)
+	}
+}
+
+// Configure is a method that configures the WorkspaceContentsDataSource.
+func (d *WorkspaceContentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerbiapi.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *powerbiapi.Client, got: %T. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read is a method that lists the contents of the workspace from the Power BI service.
+func (d *WorkspaceContentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.WorkspaceContentsData
+	var workspace *pbiModels.Group
+	var err error
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.WorkspaceId.IsNull() {
+		workspace, err = d.client.GetGroup(ctx, data.WorkspaceId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with Id %s", data.WorkspaceId.ValueString()), err.Error())
+			return
+		}
+	} else {
+		workspace, err = findWorkspaceByName(ctx, d.client, data.WorkspaceName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with name %s", data.WorkspaceName.ValueString()), err.Error())
+			return
+		}
+	}
+
+	data.WorkspaceId = types.StringValue(workspace.Id)
+	data.WorkspaceName = types.StringValue(workspace.Name)
+
+	tflog.Debug(ctx, fmt.Sprintf("Listing the contents of workspace %s", workspace.Id))
+
+	datasets, err := d.client.GetDatasetsInGroup(ctx, workspace.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the datasets of workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
+
+	data.Datasets = []models.WorkspaceDataset{}
+	for _, dataset := range datasets.Value {
+		data.Datasets = append(data.Datasets, models.WorkspaceDataset{
+			Id:            types.StringValue(dataset.Id),
+			Name:          types.StringValue(dataset.Name),
+			ConfiguredBy:  optionalString(dataset.ConfiguredBy),
+			IsRefreshable: types.BoolValue(dataset.IsRefreshable),
+			WebUrl:        optionalString(dataset.WebUrl),
+		})
+	}
+
+	reports, err := d.client.GetReportsInGroup(ctx, workspace.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the reports of workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
+
+	data.Reports = []models.WorkspaceReport{}
+	for _, report := range reports.Value {
+		data.Reports = append(data.Reports, models.WorkspaceReport{
+			Id:         types.StringValue(report.Id),
+			Name:       types.StringValue(report.Name),
+			DatasetId:  optionalString(report.DatasetId),
+			ReportType: optionalString(report.ReportType),
+			WebUrl:     optionalString(report.WebUrl),
+		})
+	}
+
+	dashboards, err := d.client.GetDashboardsInGroup(ctx, workspace.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the dashboards of workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
+
+	data.Dashboards = []models.WorkspaceDashboard{}
+	for _, dashboard := range dashboards.Value {
+		data.Dashboards = append(data.Dashboards, models.WorkspaceDashboard{
+			Id:          types.StringValue(dashboard.Id),
+			DisplayName: types.StringValue(dashboard.DisplayName),
+			IsReadOnly:  types.BoolValue(dashboard.IsReadOnly),
+			WebUrl:      optionalString(dashboard.WebUrl),
+		})
+	}
+
+	dataflows, err := d.client.GetDataflows(ctx, workspace.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the dataflows of workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
+
+	data.Dataflows = []models.WorkspaceDataflow{}
+	for _, dataflow := range dataflows.Value {
+		data.Dataflows = append(data.Dataflows, models.WorkspaceDataflow{
+			Id:           types.StringValue(dataflow.ObjectId),
+			Name:         types.StringValue(dataflow.Name),
+			Description:  optionalString(dataflow.Description),
+			ConfiguredBy: optionalString(dataflow.ConfiguredBy),
+		})
+	}
+
+	// The Power BI API does not list the datamarts, the Fabric API does when it is available.
+	data.Datamarts = nil
+	pager := d.client.NewItemsPager(workspace.Id, &powerbiapi.ItemsPagerOptions{Type: "Datamart"})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if errors.Is(err, powerbiapi.ErrFabricUnavailable) {
+			tflog.Debug(ctx, fmt.Sprintf("The Fabric API is not available, the datamarts of workspace %s are not listed", workspace.Id))
+			break
+		}
+		if powerbiapi.IsUnauthorized(err) {
+			resp.Diagnostics.AddWarning(
+				fmt.Sprintf("Cannot retrieve the datamarts of workspace with Id %s", workspace.Id),
+				fmt.Sprintf("The Fabric API denied access, the datamarts are not listed. "+
+					"Check that the provider credentials are allowed to call the Fabric API.\n\n%s", err.Error()),
+			)
+			data.Datamarts = nil
+			break
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the datamarts of workspace with Id %s", workspace.Id), err.Error())
+			return
+		}
+
+		if data.Datamarts == nil {
+			data.Datamarts = []models.WorkspaceDatamart{}
+		}
+		for _, item := range page.Value {
+			data.Datamarts = append(data.Datamarts, models.WorkspaceDatamart{
+				Id:          types.StringValue(item.Id),
+				Name:        types.StringValue(item.DisplayName),
+				Description: optionalString(item.Description),
+			})
+		}
+	}
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// optionalString returns the value as a string attribute, null when it is empty.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
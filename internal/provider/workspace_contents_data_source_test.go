@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspaceContentsDataSource lists the contents of a workspace looked up by name, paging through the datamarts.
+func TestAccWorkspaceContentsDataSource(t *testing.T) {
+	server := testAccServer(t)
+	server.SetItemsPageSize(1)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-contents"})
+	dataset := server.PutDataset(group.Id, fake.Dataset{Name: "Sales", ConfiguredBy: "owner@contoso.com", IsRefreshable: true})
+	report := server.PutReport(group.Id, fake.Report{Name: "Sales", DatasetId: dataset.Id, ReportType: "PowerBIReport"})
+	dashboard := server.PutDashboard(group.Id, fake.Dashboard{DisplayName: "Overview"})
+	dataflow := server.PutDataflow(group.Id, fake.Dataflow{Name: "Ingest"})
+	first := server.PutDatamart(group.Id, fake.Datamart{Name: "Mart A"})
+	second := server.PutDatamart(group.Id, fake.Datamart{Name: "Mart B", Description: "Second mart"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_contents" "test" {
+  workspace_name = "tf-acc-contents"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "workspace_id", group.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datasets.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datasets.0.id", dataset.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datasets.0.configured_by", "owner@contoso.com"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datasets.0.is_refreshable", "true"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "reports.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "reports.0.id", report.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "reports.0.dataset_id", dataset.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "dashboards.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "dashboards.0.id", dashboard.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "dashboards.0.display_name", "Overview"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "dataflows.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "dataflows.0.id", dataflow.ObjectId),
+					resource.TestCheckNoResourceAttr("data.powerbi_workspace_contents.test", "dataflows.0.description"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datamarts.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datamarts.0.id", first.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datamarts.1.id", second.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datamarts.1.description", "Second mart"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceContentsDataSource_withoutFabric leaves the datamarts null when the Fabric API is not available.
+func TestAccWorkspaceContentsDataSource_withoutFabric(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-contents"})
+	server.PutDatamart(group.Id, fake.Datamart{Name: "Mart A"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfigWithoutFabric(server) + `
+data "powerbi_workspace_contents" "test" {
+  workspace_id = "` + group.Id + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "workspace_name", "tf-acc-contents"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "datasets.#", "0"),
+					resource.TestCheckNoResourceAttr("data.powerbi_workspace_contents.test", "datamarts"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceContentsDataSource_fabricForbidden leaves the datamarts null when the Fabric API denies access.
+func TestAccWorkspaceContentsDataSource_fabricForbidden(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-contents"})
+	server.PutDatamart(group.Id, fake.Datamart{Name: "Mart A"})
+	server.ForbidFabric(true)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_contents" "test" {
+  workspace_id = "` + group.Id + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_contents.test", "workspace_name", "tf-acc-contents"),
+					resource.TestCheckNoResourceAttr("data.powerbi_workspace_contents.test", "datamarts"),
+				),
+			},
+		},
+	})
+}
@@ -3,14 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
-	"terraform-provider-powerbi/internal/powerbiapi"
-	pbiModels "terraform-provider-powerbi/internal/powerbiapi/models"
-	"terraform-provider-powerbi/internal/provider/models"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
 )
 
 var (
@@ -51,6 +52,36 @@ func (d *WorkspaceDataSource) Schema(_ context.Context, req datasource.SchemaReq
 				Optional:            true,
 				Computed:            true,
 			},
+			"capacity_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the capacity the workspace is assigned to, unset on the shared capacity",
+				Computed:            true,
+			},
+			"default_dataset_storage_format": schema.StringAttribute{
+				MarkdownDescription: "The default dataset storage format of the workspace, unset on the shared capacity",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the workspace, read from the Fabric API. It is null when the Fabric API is not available or denies access, with a warning in the latter case",
+				Computed:            true,
+			},
+			"log_analytics": schema.SingleNestedAttribute{
+				MarkdownDescription: "The Azure Log Analytics workspace assigned to the workspace",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"subscription_id": schema.StringAttribute{
+						MarkdownDescription: "The Azure subscription of the Log Analytics workspace",
+						Computed:            true,
+					},
+					"resource_group": schema.StringAttribute{
+						MarkdownDescription: "The resource group of the Log Analytics workspace",
+						Computed:            true,
+					},
+					"resource_name": schema.StringAttribute{
+						MarkdownDescription: "The name of the Log Analytics workspace",
+						Computed:            true,
+					},
+				},
+			},
 			"is_read_only": schema.BoolAttribute{
 				MarkdownDescription: "Indicates whether the workspace is read-only",
 				Computed:            true,
@@ -73,7 +104,7 @@ func (d *WorkspaceDataSource) Schema(_ context.Context, req datasource.SchemaReq
 //
 // Returns: None.
 func (d *WorkspaceDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
-	var data models.Workspace
+	var data models.WorkspaceData
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
@@ -132,7 +163,7 @@ func (d *WorkspaceDataSource) Configure(ctx context.Context, req datasource.Conf
 //
 // Returns: None.
 func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data models.Workspace
+	var data models.WorkspaceData
 	var workspace *pbiModels.Group
 	var err error
 
@@ -143,7 +174,7 @@ func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	if !data.Id.IsNull() {
-		workspace, err = d.client.GetGroup(data.Id.ValueString())
+		workspace, err = d.client.GetGroup(ctx, data.Id.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with Id %s", data.Id.ValueString()), err.Error())
 			return
@@ -151,7 +182,7 @@ func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	if !data.Name.IsNull() {
-		workspaces, err := d.client.GetGroups(fmt.Sprintf("name eq '%s'", data.Name.ValueString()), 0, 0)
+		workspaces, err := d.client.GetGroups(ctx, fmt.Sprintf("name eq '%s'", data.Name.ValueString()), 0, 0)
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with name %s", data.Name.ValueString()), err.Error())
 			return
@@ -175,10 +206,17 @@ func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	data.Id = types.StringValue(workspace.Id)
-	data.Name = types.StringValue(workspace.Name)
-	data.IsReadOnly = types.BoolValue(workspace.IsReadOnly)
-	data.IsOnDedicatedCapacity = types.BoolValue(workspace.IsOnDedicatedCapacity)
+	var state models.Workspace
+	setWorkspaceState(&state, workspace)
+	state.Description = types.StringUnknown()
+
+	err = readWorkspaceDescription(ctx, d.client, workspace.Id, &state.Description, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the description of workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
+
+	data = state.Data()
 
 	diags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspaceDataSource looks up a workspace by id and by name.
+func TestAccWorkspaceDataSource(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-lookup", IsOnDedicatedCapacity: true, CapacityId: "FEFC7A26-4758-41CD-9069-5E73B7E9DB0E"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read by id
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace" "test" {
+  id = "` + group.Id + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "name", "tf-acc-lookup"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "is_on_dedicated_capacity", "true"),
+				),
+			},
+			// Read by name
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace" "test" {
+  name = "tf-acc-lookup"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "id", group.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "is_read_only", "false"),
+				),
+			},
+			// Unknown name
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace" "test" {
+  name = "tf-acc-missing"
+}
+`,
+				ExpectError: regexp.MustCompile("No groups found"),
+			},
+		},
+	})
+}
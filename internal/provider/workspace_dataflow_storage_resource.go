@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+)
+
+var _ resource.Resource = &WorkspaceDataflowStorageResource{}                // Ensure that WorkspaceDataflowStorageResource implements the Resource interface.
+var _ resource.ResourceWithImportState = &WorkspaceDataflowStorageResource{} // Ensure that WorkspaceDataflowStorageResource implements the ResourceWithImportState interface.
+var _ resource.ResourceWithConfigure = &WorkspaceDataflowStorageResource{}   // Ensure that WorkspaceDataflowStorageResource implements the ResourceWithConfigure interface.
+
+// NewWorkspaceDataflowStorageResource is a function that creates a new instance of the WorkspaceDataflowStorageResource.
+func NewWorkspaceDataflowStorageResource() resource.Resource {
+	return &WorkspaceDataflowStorageResource{}
+}
+
+// WorkspaceDataflowStorageResource is a struct that represents the assignment of a Power BI workspace
+// to a dataflow storage account.
+type WorkspaceDataflowStorageResource struct {
+	client *powerbiapi.Client
+}
+
+// Configure configures the WorkspaceDataflowStorageResource.
+func (r *WorkspaceDataflowStorageResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerbiapi.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *powerbiapi.Client, got: %T. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create assigns the workspace to the dataflow storage account.
+func (r *WorkspaceDataflowStorageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.WorkspaceDataflowStorage
+	var err error
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Assigning workspace %s to dataflow storage account %s", plan.WorkspaceId.ValueString(), plan.DataflowStorageId.ValueString()))
+	err = r.client.AssignToDataflowStorage(ctx, plan.WorkspaceId.ValueString(), plan.DataflowStorageId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot assign workspace with Id %s to dataflow storage account %s", plan.WorkspaceId.ValueString(), plan.DataflowStorageId.ValueString()), err.Error())
+		return
+	}
+
+	plan.Id = plan.WorkspaceId
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read reads the dataflow storage account the workspace is assigned to.
+// The resource is removed from the state when the workspace is gone or no longer assigned to an account.
+func (r *WorkspaceDataflowStorageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.WorkspaceDataflowStorage
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading the dataflow storage account of workspace %s", state.Id.ValueString()))
+	workspace, err := r.client.GetGroup(ctx, state.Id.ValueString())
+	if powerbiapi.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Workspace with Id %s not found, removing its dataflow storage assignment from the state", state.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with Id %s", state.Id.ValueString()), err.Error())
+		return
+	}
+
+	if workspace.DataflowStorageId == "" {
+		tflog.Warn(ctx, fmt.Sprintf("Workspace with Id %s is not assigned to a dataflow storage account, removing the assignment from the state", workspace.Id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.WorkspaceId = types.StringValue(workspace.Id)
+	// The id is kept as configured while it designates the same account, ids are compared ignoring case.
+	if !strings.EqualFold(state.DataflowStorageId.ValueString(), workspace.DataflowStorageId) {
+		state.DataflowStorageId = types.StringValue(workspace.DataflowStorageId)
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update assigns the workspace to another dataflow storage account.
+func (r *WorkspaceDataflowStorageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.WorkspaceDataflowStorage
+	var err error
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Assigning workspace %s to dataflow storage account %s", plan.WorkspaceId.ValueString(), plan.DataflowStorageId.ValueString()))
+	err = r.client.AssignToDataflowStorage(ctx, plan.WorkspaceId.ValueString(), plan.DataflowStorageId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot assign workspace with Id %s to dataflow storage account %s", plan.WorkspaceId.ValueString(), plan.DataflowStorageId.ValueString()), err.Error())
+		return
+	}
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete unassigns the workspace from its dataflow storage account.
+func (r *WorkspaceDataflowStorageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.WorkspaceDataflowStorage
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Unassigning workspace %s from its dataflow storage account", state.Id.ValueString()))
+	err := r.client.UnassignFromDataflowStorage(ctx, state.Id.ValueString())
+	if err != nil && !powerbiapi.IsNotFound(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot unassign workspace with Id %s from its dataflow storage account", state.Id.ValueString()), err.Error())
+		return
+	}
+}
+
+// ImportState imports the dataflow storage assignment of a workspace by the workspace ID.
+func (r *WorkspaceDataflowStorageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Metadata sets the metadata for the WorkspaceDataflowStorageResource.
+func (r *WorkspaceDataflowStorageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspace_dataflow_storage"
+}
+
+// Schema sets the schema for the WorkspaceDataflowStorageResource.
+func (r *WorkspaceDataflowStorageResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Assigns a Power BI workspace to a dataflow storage account, an Azure Data Lake Storage Gen2 account registered in the tenant. The workspace is unassigned when the resource is destroyed",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the workspace",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the workspace",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dataflow_storage_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the dataflow storage account, as listed by the `powerbi_dataflow_storage_accounts` data source, compared ignoring case",
+				Required:            true,
+			},
+		},
+	}
+}
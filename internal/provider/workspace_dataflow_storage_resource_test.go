@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspaceDataflowStorageResource assigns a workspace to a dataflow storage account, imports the assignment,
+// moves the workspace to another account, repairs an unassignment made in the portal, and unassigns the workspace.
+func TestAccWorkspaceDataflowStorageResource(t *testing.T) {
+	server := testAccServer(t)
+	lake := server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "lake", IsEnabled: true})
+	lake2 := server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "lake2", IsEnabled: true})
+	archive := server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "archive"})
+	group := server.PutGroup(fake.Group{Name: "tf-acc-dataflows"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDataflowStorage(server, group.Id, ""),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWorkspaceDataflowStorageResourceConfig(server, group.Id, archive.Id),
+				ExpectError: regexp.MustCompile(`DataflowStorageAccountDisabled`),
+			},
+			{
+				Config: testAccWorkspaceDataflowStorageResourceConfig(server, group.Id, lake.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace_dataflow_storage.test", "id", group.Id),
+					resource.TestCheckResourceAttr("powerbi_workspace_dataflow_storage.test", "dataflow_storage_id", lake.Id),
+					testAccCheckWorkspaceDataflowStorage(server, group.Id, lake.Id),
+				),
+			},
+			{
+				ResourceName:      "powerbi_workspace_dataflow_storage.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccWorkspaceDataflowStorageResourceConfig(server, group.Id, lake2.Id),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_dataflow_storage.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckWorkspaceDataflowStorage(server, group.Id, lake2.Id),
+			},
+			{
+				// An id differing only in case designates the same account.
+				Config: testAccWorkspaceDataflowStorageResourceConfig(server, group.Id, strings.ToUpper(lake2.Id)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_dataflow_storage.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckWorkspaceDataflowStorage(server, group.Id, lake2.Id),
+			},
+			{
+				Config: testAccWorkspaceDataflowStorageResourceConfig(server, group.Id, strings.ToUpper(lake2.Id)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Drift testing: the workspace is unassigned outside of Terraform, the next apply assigns it again.
+			{
+				PreConfig: func() {
+					g, _ := server.Group(group.Id)
+					g.DataflowStorageId = ""
+					server.PutGroup(g)
+				},
+				Config: testAccWorkspaceDataflowStorageResourceConfig(server, group.Id, lake2.Id),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_dataflow_storage.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckWorkspaceDataflowStorage(server, group.Id, lake2.Id),
+			},
+		},
+	})
+}
+
+func testAccWorkspaceDataflowStorageResourceConfig(server *fake.Server, workspaceId string, dataflowStorageId string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_dataflow_storage" "test" {
+  workspace_id        = %q
+  dataflow_storage_id = %q
+}
+`, workspaceId, dataflowStorageId)
+}
+
+// testAccCheckWorkspaceDataflowStorage checks the dataflow storage account of the workspace on the service side,
+// empty when the workspace is not assigned to an account.
+func testAccCheckWorkspaceDataflowStorage(server *fake.Server, workspaceId string, dataflowStorageId string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		group, ok := server.Group(workspaceId)
+		if !ok {
+			return fmt.Errorf("workspace %s does not exist", workspaceId)
+		}
+		if group.DataflowStorageId != dataflowStorageId {
+			return fmt.Errorf("workspace %s is assigned to the dataflow storage account %q, expected %q", workspaceId, group.DataflowStorageId, dataflowStorageId)
+		}
+		return nil
+	}
+}
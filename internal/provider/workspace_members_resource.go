@@ -0,0 +1,424 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+var _ resource.Resource = &WorkspaceMembersResource{}                   // Ensure that WorkspaceMembersResource implements the Resource interface.
+var _ resource.ResourceWithImportState = &WorkspaceMembersResource{}    // Ensure that WorkspaceMembersResource implements the ResourceWithImportState interface.
+var _ resource.ResourceWithConfigure = &WorkspaceMembersResource{}      // Ensure that WorkspaceMembersResource implements the ResourceWithConfigure interface.
+var _ resource.ResourceWithValidateConfig = &WorkspaceMembersResource{} // Ensure that WorkspaceMembersResource implements the ResourceWithValidateConfig interface.
+
+// NewWorkspaceMembersResource is a function that creates a new instance of the WorkspaceMembersResource.
+func NewWorkspaceMembersResource() resource.Resource {
+	return &WorkspaceMembersResource{}
+}
+
+// WorkspaceMembersResource is a struct that represents the complete list of the members of a Power BI workspace.
+// The members that are not listed are removed, except the protected principals which are left untouched.
+type WorkspaceMembersResource struct {
+	client *powerbiapi.Client
+}
+
+// ValidateConfig validates the configuration for the WorkspaceMembersResource.
+// Each member is given like in the workspace permission resource, is listed once and is not protected.
+func (*WorkspaceMembersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var membersSet, protectedSet types.Set
+	var members []models.WorkspaceMember
+	var protectedPrincipals []types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("members"), &membersSet)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("protected_principals"), &protectedSet)...)
+
+	// The sets may only be known at apply time.
+	if resp.Diagnostics.HasError() || membersSet.IsUnknown() || protectedSet.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(membersSet.ElementsAs(ctx, &members, false)...)
+	resp.Diagnostics.Append(protectedSet.ElementsAs(ctx, &protectedPrincipals, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	protected := protectedPrincipalSet(protectedPrincipals)
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		memberPath := path.Root("members").AtSetValue(workspaceMemberValue(member))
+		validatePrincipalConfig(memberPath, member.PrincipalType, member.EmailAddress, member.Identifier, &resp.Diagnostics)
+
+		if member.EmailAddress.IsUnknown() || member.Identifier.IsUnknown() {
+			continue
+		}
+
+		principal := strings.ToLower(member.Principal())
+		if seen[principal] {
+			resp.Diagnostics.AddAttributeError(
+				memberPath,
+				"Invalid attribute configuration",
+				fmt.Sprintf("%s is listed more than once in 'members'", member.Principal()),
+			)
+		}
+		if protected[principal] {
+			resp.Diagnostics.AddAttributeError(
+				memberPath,
+				"Invalid attribute configuration",
+				fmt.Sprintf("%s is listed in both 'members' and 'protected_principals'", member.Principal()),
+			)
+		}
+		seen[principal] = true
+	}
+}
+
+// Configure configures the WorkspaceMembersResource.
+func (r *WorkspaceMembersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerbiapi.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *powerbiapi.Client, got: %T. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create makes the members of the workspace match the configured ones.
+func (r *WorkspaceMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.WorkspaceMembers
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyMembers(ctx, plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = plan.WorkspaceId
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete only removes the members from the state, the members of the workspace are kept.
+// Removing them could revoke the access of the principal Terraform authenticates with, before the workspace itself is destroyed.
+func (r *WorkspaceMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.WorkspaceMembers
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Removing the members of workspace %s from the state, the members are kept", state.WorkspaceId.ValueString()))
+}
+
+// ImportState imports the members of a workspace by the workspace id.
+func (r *WorkspaceMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_id"), req.ID)...)
+}
+
+// Metadata sets the metadata for the WorkspaceMembersResource.
+func (r *WorkspaceMembersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspace_members"
+}
+
+// Read updates the state with the members of the workspace, except the protected principals.
+// The members added outside of Terraform are read too, so that the next plan removes them.
+func (r *WorkspaceMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.WorkspaceMembers
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading the members of workspace %s", state.WorkspaceId.ValueString()))
+	users, err := listGroupUsers(ctx, r.client, state.WorkspaceId.ValueString())
+	if powerbiapi.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Workspace with Id %s not found, removing its members from the state", state.WorkspaceId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the users of workspace with Id %s", state.WorkspaceId.ValueString()), err.Error())
+		return
+	}
+
+	protected := protectedPrincipalSet(state.ProtectedPrincipals)
+	current := make(map[string]pbiModels.GroupUser, len(users))
+	for _, user := range users {
+		if !protected[strings.ToLower(groupUserPrincipal(user))] {
+			current[strings.ToLower(groupUserPrincipal(user))] = user
+		}
+	}
+
+	// The known members keep the principal as configured, which may differ in case from the one returned by the service.
+	members := []models.WorkspaceMember{}
+	for _, member := range state.Members {
+		user, ok := current[strings.ToLower(member.Principal())]
+		if !ok {
+			continue
+		}
+		delete(current, strings.ToLower(member.Principal()))
+
+		member.PrincipalType = types.StringValue(string(user.PrincipalType))
+		member.AccessRight = types.StringValue(string(user.GroupUserAccessRight))
+		members = append(members, member)
+	}
+	for _, user := range users {
+		if _, ok := current[strings.ToLower(groupUserPrincipal(user))]; ok {
+			members = append(members, workspaceMember(user))
+		}
+	}
+	state.Members = members
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Schema sets the schema for the WorkspaceMembersResource.
+func (r *WorkspaceMembersResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Declares the complete list of the members of a Power BI workspace. The users, groups and service principals that are not listed are removed from the workspace, " +
+			"except the protected principals. The principal the provider authenticates with must be listed in `members` or `protected_principals`, otherwise it loses its access to the workspace. " +
+			"Destroying the resource keeps the members of the workspace",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the workspace",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the workspace",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"members": schema.SetNestedAttribute{
+				MarkdownDescription: "The members of the workspace",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"email_address": schema.StringAttribute{
+							MarkdownDescription: "The email address of the user. Required when `principal_type` is `User`",
+							Optional:            true,
+						},
+						"identifier": schema.StringAttribute{
+							MarkdownDescription: "The object id of the group or the service principal. Required when `principal_type` is `Group` or `App`",
+							Optional:            true,
+						},
+						"principal_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the principal, `User`, `Group` or `App`",
+							Required:            true,
+							Validators: []validator.String{
+								principalTypeValidator(),
+							},
+						},
+						"access_right": schema.StringAttribute{
+							MarkdownDescription: "The access right of the principal, `Admin`, `Member`, `Contributor` or `Viewer`",
+							Required:            true,
+							Validators: []validator.String{
+								accessRightValidator(),
+							},
+						},
+					},
+				},
+			},
+			"protected_principals": schema.SetAttribute{
+				MarkdownDescription: "The email addresses of the users and the identifiers of the groups and service principals that are never added, updated nor removed, whatever their access right",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+// Update makes the members of the workspace match the configured ones.
+func (r *WorkspaceMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.WorkspaceMembers
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyMembers(ctx, plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// applyMembers diffs the planned members against the users of the workspace.
+// The missing members are added and the access rights updated before the users that are not listed are removed,
+// so that the workspace always keeps an admin.
+func (r *WorkspaceMembersResource) applyMembers(ctx context.Context, plan models.WorkspaceMembers) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	workspaceId := plan.WorkspaceId.ValueString()
+
+	users, err := listGroupUsers(ctx, r.client, workspaceId)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Cannot retrieve the users of workspace with Id %s", workspaceId), err.Error())
+		return diags
+	}
+
+	current := make(map[string]pbiModels.GroupUser, len(users))
+	for _, user := range users {
+		current[strings.ToLower(groupUserPrincipal(user))] = user
+	}
+
+	desired := make(map[string]bool, len(plan.Members))
+	for _, member := range plan.Members {
+		desired[strings.ToLower(member.Principal())] = true
+		request := groupUserRequest(member.EmailAddress, member.Identifier, member.PrincipalType, member.AccessRight)
+
+		user, ok := current[strings.ToLower(member.Principal())]
+		switch {
+		case !ok:
+			tflog.Debug(ctx, fmt.Sprintf("Granting %s %s access to workspace %s", member.Principal(), member.AccessRight.ValueString(), workspaceId))
+			if err := r.client.AddGroupUser(ctx, workspaceId, request); err != nil {
+				diags.AddError(fmt.Sprintf("Cannot grant %s access to workspace with Id %s", member.Principal(), workspaceId), err.Error())
+				return diags
+			}
+		case string(user.GroupUserAccessRight) != member.AccessRight.ValueString():
+			tflog.Debug(ctx, fmt.Sprintf("Changing the access of %s to workspace %s to %s", member.Principal(), workspaceId, member.AccessRight.ValueString()))
+			if err := r.client.UpdateGroupUser(ctx, workspaceId, request); err != nil {
+				diags.AddError(fmt.Sprintf("Cannot change the access of %s to workspace with Id %s", member.Principal(), workspaceId), err.Error())
+				return diags
+			}
+		}
+	}
+
+	protected := protectedPrincipalSet(plan.ProtectedPrincipals)
+	for _, user := range users {
+		principal := groupUserPrincipal(user)
+		if desired[strings.ToLower(principal)] || protected[strings.ToLower(principal)] {
+			continue
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Revoking the access of %s to workspace %s", principal, workspaceId))
+		err := r.client.DeleteUserGroup(ctx, workspaceId, principal)
+		if err != nil && !powerbiapi.IsNotFound(err) {
+			diags.AddError(fmt.Sprintf("Cannot revoke the access of %s to workspace with Id %s", principal, workspaceId), err.Error())
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// listGroupUsers returns all the users of the workspace.
+func listGroupUsers(ctx context.Context, client *powerbiapi.Client, workspaceId string) ([]pbiModels.GroupUser, error) {
+	var users []pbiModels.GroupUser
+
+	pager := client.NewGroupUsersPager(workspaceId, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page.Value...)
+	}
+
+	return users, nil
+}
+
+// groupUserPrincipal returns the email address of a user, or the identifier of a group or a service principal.
+func groupUserPrincipal(user pbiModels.GroupUser) string {
+	if user.PrincipalType == pbiModels.PrincipalTypeUser && user.EmailAddress != "" {
+		return user.EmailAddress
+	}
+	return user.Identifier
+}
+
+// workspaceMember returns the member matching a user of the workspace.
+func workspaceMember(user pbiModels.GroupUser) models.WorkspaceMember {
+	member := models.WorkspaceMember{
+		EmailAddress:  types.StringNull(),
+		Identifier:    types.StringNull(),
+		PrincipalType: types.StringValue(string(user.PrincipalType)),
+		AccessRight:   types.StringValue(string(user.GroupUserAccessRight)),
+	}
+	if user.PrincipalType == pbiModels.PrincipalTypeUser {
+		member.EmailAddress = types.StringValue(groupUserPrincipal(user))
+	} else {
+		member.Identifier = types.StringValue(user.Identifier)
+	}
+	return member
+}
+
+// workspaceMemberValue returns the object value of a member, used to report an error on a member of the set.
+func workspaceMemberValue(member models.WorkspaceMember) types.Object {
+	return types.ObjectValueMust(
+		map[string]attr.Type{
+			"email_address":  types.StringType,
+			"identifier":     types.StringType,
+			"principal_type": types.StringType,
+			"access_right":   types.StringType,
+		},
+		map[string]attr.Value{
+			"email_address":  member.EmailAddress,
+			"identifier":     member.Identifier,
+			"principal_type": member.PrincipalType,
+			"access_right":   member.AccessRight,
+		},
+	)
+}
+
+// protectedPrincipalSet returns the protected principals in lower case.
+func protectedPrincipalSet(principals []types.String) map[string]bool {
+	protected := make(map[string]bool, len(principals))
+	for _, principal := range principals {
+		protected[strings.ToLower(principal.ValueString())] = true
+	}
+	return protected
+}
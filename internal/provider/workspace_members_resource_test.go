@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspaceMembersResource declares the members of a workspace, removing the users that are not listed,
+// changes an access right, removes a user added in the portal, and keeps the protected principals and the members on destroy.
+func TestAccWorkspaceMembersResource(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-members"})
+	server.PutGroupUser(group.Id, fake.GroupUser{Identifier: fake.CallerId, GroupUserAccessRight: "Admin", PrincipalType: "App"})
+	server.PutGroupUser(group.Id, fake.GroupUser{Identifier: "jane.doe@contoso.com", EmailAddress: "jane.doe@contoso.com", GroupUserAccessRight: "Admin", PrincipalType: "User"})
+	sales := "796131c3-8d85-44e1-bdfc-88ad8ba46520"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckWorkspacePermission(server, group.Id, fake.CallerId, "Admin"),
+			testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", "Member"),
+			testAccCheckWorkspacePermission(server, group.Id, sales, "Viewer"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkspaceMembersResourceConfig(server, group.Id, "Contributor"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace_members.test", "id", group.Id),
+					resource.TestCheckResourceAttr("powerbi_workspace_members.test", "members.#", "2"),
+					testAccCheckWorkspacePermission(server, group.Id, fake.CallerId, "Admin"),
+					testAccCheckWorkspacePermission(server, group.Id, "jane.doe@contoso.com", ""),
+					testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", "Contributor"),
+					testAccCheckWorkspacePermission(server, group.Id, sales, "Viewer"),
+				),
+			},
+			{
+				ResourceName:            "powerbi_workspace_members.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"members", "protected_principals"},
+			},
+			{
+				Config: testAccWorkspaceMembersResourceConfig(server, group.Id, "Member"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_members.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", "Member"),
+			},
+			// Drift testing: a user is added in the portal, the next apply removes it.
+			{
+				PreConfig: func() {
+					server.PutGroupUser(group.Id, fake.GroupUser{Identifier: "mallory@contoso.com", EmailAddress: "mallory@contoso.com", GroupUserAccessRight: "Admin", PrincipalType: "User"})
+				},
+				Config: testAccWorkspaceMembersResourceConfig(server, group.Id, "Member"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_members.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckWorkspacePermission(server, group.Id, "mallory@contoso.com", ""),
+					testAccCheckWorkspacePermission(server, group.Id, fake.CallerId, "Admin"),
+				),
+			},
+			// Drift testing: a member is removed in the portal, the next apply grants it again.
+			{
+				PreConfig: func() {
+					server.RemoveGroupUser(group.Id, sales)
+				},
+				Config: testAccWorkspaceMembersResourceConfig(server, group.Id, "Member"),
+				Check:  testAccCheckWorkspacePermission(server, group.Id, sales, "Viewer"),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceMembersResource_invalid rejects a member listed twice, a protected member and a group given by email address.
+func TestAccWorkspaceMembersResource_invalid(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-members"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_members" "test" {
+  workspace_id = %q
+  members = [
+    { email_address = "john.doe@contoso.com", principal_type = "User", access_right = "Admin" },
+    { email_address = "John.Doe@contoso.com", principal_type = "User", access_right = "Viewer" },
+  ]
+}
+`, group.Id),
+				ExpectError: regexp.MustCompile(`is listed more than once in 'members'`),
+			},
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_members" "test" {
+  workspace_id         = %q
+  protected_principals = ["john.doe@contoso.com"]
+  members = [
+    { email_address = "john.doe@contoso.com", principal_type = "User", access_right = "Admin" },
+  ]
+}
+`, group.Id),
+				ExpectError: regexp.MustCompile(`is listed in both 'members' and 'protected_principals'`),
+			},
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_members" "test" {
+  workspace_id = %q
+  members = [
+    { email_address = "sales@contoso.com", principal_type = "Group", access_right = "Admin" },
+  ]
+}
+`, group.Id),
+				ExpectError: regexp.MustCompile(`a group or a service principal is given by 'identifier'`),
+			},
+		},
+	})
+}
+
+// testAccWorkspaceMembersResourceConfig returns the configuration declaring john.doe@contoso.com with an access right
+// and the sales group as viewer as the members of the workspace, the fake caller being protected.
+func testAccWorkspaceMembersResourceConfig(server *fake.Server, workspaceId string, accessRight string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_members" "test" {
+  workspace_id         = %q
+  protected_principals = [%q]
+  members = [
+    {
+      email_address  = "john.doe@contoso.com"
+      principal_type = "User"
+      access_right   = %q
+    },
+    {
+      identifier     = "796131c3-8d85-44e1-bdfc-88ad8ba46520"
+      principal_type = "Group"
+      access_right   = "Viewer"
+    },
+  ]
+}
+`, workspaceId, fake.CallerId, accessRight)
+}
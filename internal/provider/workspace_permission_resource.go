@@ -2,30 +2,88 @@ package provider
 
 import (
 	"context"
-	"terraform-provider-powerbi/internal/powerbiapi"
+	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
 )
 
 var _ resource.Resource = &WorkspacePermissionResource{}                   // Ensure that WorkspacePermissionResource implements the Resource interface.
 var _ resource.ResourceWithImportState = &WorkspacePermissionResource{}    // Ensure that WorkspacePermissionResource implements the ResourceWithImportState interface.
 var _ resource.ResourceWithConfigure = &WorkspacePermissionResource{}      // Ensure that WorkspacePermissionResource implements the ResourceWithConfigure interface.
 var _ resource.ResourceWithValidateConfig = &WorkspacePermissionResource{} // Ensure that WorkspacePermissionResource implements the ResourceWithValidateConfig interface.
+var _ resource.ResourceWithModifyPlan = &WorkspacePermissionResource{}     // Ensure that WorkspacePermissionResource implements the ResourceWithModifyPlan interface.
 
 // NewWorkspacePermissionResource is a function that creates a new instance of the WorkspacePermissionResource.
 func NewWorkspacePermissionResource() resource.Resource {
 	return &WorkspacePermissionResource{}
 }
 
-// WorkspacePermissionResource is a struct that represents the Power BI workspace resource.
+// WorkspacePermissionResource is a struct that represents the access of a single principal to a Power BI workspace.
+// It only manages this principal: the other users of the workspace are left untouched.
 type WorkspacePermissionResource struct {
 	client *powerbiapi.Client
 }
 
 // ValidateConfig validates the configuration for the WorkspacePermissionResource.
-func (*WorkspacePermissionResource) ValidateConfig(context.Context, resource.ValidateConfigRequest, *resource.ValidateConfigResponse) {
-	panic("unimplemented")
+// A user is given by its email address, a group or a service principal by its identifier,
+// unless the principal is given by one of the attributes resolved through Microsoft Graph.
+func (*WorkspacePermissionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.WorkspacePermissionAssignment
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	given := 0
+	for _, value := range []types.String{data.EmailAddress, data.Identifier, data.UserPrincipalName, data.GroupDisplayName, data.ApplicationId} {
+		if !value.IsNull() {
+			given++
+		}
+	}
+	if given != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid attribute combination",
+			"exactly one of 'email_address', 'identifier', 'user_principal_name', 'group_display_name' or 'application_id' must be set",
+		)
+		return
+	}
+
+	resolvedType, resolvedBy := resolvedPrincipalType(data)
+	switch {
+	case resolvedBy != "":
+		if !data.PrincipalType.IsUnknown() && !data.PrincipalType.IsNull() && data.PrincipalType.ValueString() != string(resolvedType) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("principal_type"),
+				"Invalid attribute configuration",
+				fmt.Sprintf("'%s' gives a principal of type %s, 'principal_type' must be %s or not set", resolvedBy, resolvedType, resolvedType),
+			)
+		}
+	case data.PrincipalType.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("principal_type"),
+			"Missing required argument",
+			"'principal_type' is required with 'email_address' or 'identifier'",
+		)
+	default:
+		validatePrincipalConfig(path.Empty(), data.PrincipalType, data.EmailAddress, data.Identifier, &resp.Diagnostics)
+	}
 }
 
 // Configure configures the WorkspacePermissionResource.
@@ -49,19 +107,92 @@ func (r *WorkspacePermissionResource) Configure(_ context.Context, req resource.
 	r.client = client
 }
 
-// Create creates a new Power BI workspace permission.
+// Create grants the principal access to the workspace.
 func (r *WorkspacePermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	panic("unimplemented")
+	var plan models.WorkspacePermissionAssignment
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Granting %s %s access to workspace %s", plan.Principal(), plan.AccessRight.ValueString(), plan.WorkspaceId.ValueString()))
+	err := r.client.AddGroupUser(ctx, plan.WorkspaceId.ValueString(), groupUserRequest(plan.EmailAddress, plan.Identifier, plan.PrincipalType, plan.AccessRight))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot grant %s access to workspace with Id %s", plan.Principal(), plan.WorkspaceId.ValueString()), err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(plan.WorkspaceId.ValueString() + "/" + plan.Principal())
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
 }
 
-// Delete deletes the Power BI workspace permission.
+// Delete revokes the access of the principal to the workspace.
 func (r *WorkspacePermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	panic("unimplemented")
+	var state models.WorkspacePermissionAssignment
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Revoking the access of %s to workspace %s", state.Principal(), state.WorkspaceId.ValueString()))
+	err := r.client.DeleteUserGroup(ctx, state.WorkspaceId.ValueString(), state.Principal())
+	if err != nil && !powerbiapi.IsNotFound(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot revoke the access of %s to workspace with Id %s", state.Principal(), state.WorkspaceId.ValueString()), err.Error())
+		return
+	}
 }
 
-// ImportState implements resource.ResourceWithImportState.
-func (r *WorkspacePermissionResource) ImportState(_ context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	panic("unimplemented")
+// ImportState imports the access of a principal to a workspace, by an ID of the form <workspace_id>/<principal>,
+// the principal being the email address of a user or the identifier of a group or a service principal.
+func (r *WorkspacePermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var state models.WorkspacePermissionAssignment
+
+	workspaceId, principal, ok := strings.Cut(req.ID, "/")
+	if !ok || workspaceId == "" || principal == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form <workspace_id>/<principal>, got %q", req.ID),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Importing the access of %s to workspace %s", principal, workspaceId))
+	user, err := findGroupUser(ctx, r.client, workspaceId, principal)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot import the access of %s to workspace with Id %s", principal, workspaceId), err.Error())
+		return
+	}
+	if user == nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Cannot import the access of %s to workspace with Id %s", principal, workspaceId),
+			fmt.Sprintf("%s has no access to the workspace", principal),
+		)
+		return
+	}
+
+	state.WorkspaceId = types.StringValue(workspaceId)
+	state.PrincipalType = types.StringValue(string(user.PrincipalType))
+	if user.PrincipalType == pbiModels.PrincipalTypeUser {
+		state.EmailAddress = types.StringValue(principal)
+		state.Identifier = types.StringNull()
+	} else {
+		state.EmailAddress = types.StringNull()
+		state.Identifier = types.StringValue(principal)
+	}
+	state.AccessRight = types.StringValue(string(user.GroupUserAccessRight))
+	state.UserPrincipalName = types.StringNull()
+	state.GroupDisplayName = types.StringNull()
+	state.ApplicationId = types.StringNull()
+	state.Id = types.StringValue(workspaceId + "/" + principal)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Metadata sets the metadata for the WorkspacePermissionResource.
@@ -69,26 +200,374 @@ func (r *WorkspacePermissionResource) Metadata(_ context.Context, req resource.M
 	resp.TypeName = req.ProviderTypeName + "_workspace_permission"
 }
 
+// ModifyPlan resolves the principal given by user principal name, group name or application id through Microsoft Graph,
+// so the plan shows the email address or the identifier granted access. The resource is replaced when the principal changes.
+func (r *WorkspacePermissionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to resolve when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config models.WorkspacePermissionAssignment
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.EmailAddress = config.EmailAddress
+	plan.Identifier = config.Identifier
+	plan.PrincipalType = config.PrincipalType
+
+	if resolvedType, resolvedBy := resolvedPrincipalType(config); resolvedBy != "" {
+		plan.PrincipalType = types.StringValue(string(resolvedType))
+
+		principal, err := r.resolvePrincipal(ctx, config)
+		if errors.Is(err, powerbiapi.ErrGraphUnavailable) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(resolvedBy),
+				"Cannot resolve the principal",
+				"Microsoft Graph is not used with a custom 'base_url': set 'graph_base_url' in the provider configuration, or give the principal by 'email_address' or 'identifier'",
+			)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(resolvedBy), "Cannot resolve the principal", err.Error())
+			return
+		}
+
+		if resolvedType == pbiModels.PrincipalTypeUser {
+			plan.EmailAddress = principal
+			plan.Identifier = types.StringNull()
+		} else {
+			plan.EmailAddress = types.StringNull()
+			plan.Identifier = principal
+		}
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state models.WorkspacePermissionAssignment
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// A resolved principal is compared ignoring the case, like the service does, and keeps its case in the state.
+		if config.EmailAddress.IsNull() && samePrincipal(state.EmailAddress, plan.EmailAddress) {
+			plan.EmailAddress = state.EmailAddress
+		}
+		if config.Identifier.IsNull() && samePrincipal(state.Identifier, plan.Identifier) {
+			plan.Identifier = state.Identifier
+		}
+		if !plan.EmailAddress.Equal(state.EmailAddress) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("email_address"))
+		}
+		if !plan.Identifier.Equal(state.Identifier) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("identifier"))
+		}
+		if !plan.PrincipalType.Equal(state.PrincipalType) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("principal_type"))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 // Read updates the state with the data from the Power BI service.
+// The resource is removed from the state when the workspace is gone or the principal no longer has access to it.
 func (r *WorkspacePermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	panic("unimplemented")
+	var state models.WorkspacePermissionAssignment
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading the access of %s to workspace %s", state.Principal(), state.WorkspaceId.ValueString()))
+	user, err := findGroupUser(ctx, r.client, state.WorkspaceId.ValueString(), state.Principal())
+	if powerbiapi.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Workspace with Id %s not found, removing the access of %s from the state", state.WorkspaceId.ValueString(), state.Principal()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the users of workspace with Id %s", state.WorkspaceId.ValueString()), err.Error())
+		return
+	}
+
+	if user == nil {
+		tflog.Warn(ctx, fmt.Sprintf("%s has no access to workspace with Id %s, removing it from the state", state.Principal(), state.WorkspaceId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.AccessRight = types.StringValue(string(user.GroupUserAccessRight))
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Schema sets the schema for the WorkspacePermissionResource.
 func (r *WorkspacePermissionResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Power BI workspace permission resource",
+		MarkdownDescription: "Grants a single user, group or service principal access to a Power BI workspace. The other users of the workspace are left untouched. " +
+			"The principal is given by email address or object id, or by user principal name, group name or application id resolved through Microsoft Graph: " +
+			"the provider then needs the `User.Read.All`, `GroupMember.Read.All` and `Application.Read.All` Microsoft Graph permissions",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the workspace and the principal, separated by a slash",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"workspace_id": schema.StringAttribute{
-				MarkdownDescription: "The name of the workspace",
+				MarkdownDescription: "The id of the workspace",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email_address": schema.StringAttribute{
+				MarkdownDescription: "The email address of the user. Set it, or `user_principal_name`, when `principal_type` is `User`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"identifier": schema.StringAttribute{
+				MarkdownDescription: "The object id of the group or the service principal. Set it, `group_display_name` or `application_id` when `principal_type` is `Group` or `App`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"user_principal_name": schema.StringAttribute{
+				MarkdownDescription: "The user principal name of the user, resolved through Microsoft Graph into `email_address` at plan time",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"group_display_name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the group, resolved through Microsoft Graph into `identifier` at plan time. It must match exactly one group",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"application_id": schema.StringAttribute{
+				MarkdownDescription: "The application (client) id of the service principal, resolved through Microsoft Graph into the object id of the service principal, `identifier`, at plan time",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"principal_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the principal, `User`, `Group` or `App`. Required with `email_address` or `identifier`, it is deduced from the attribute resolved through Microsoft Graph otherwise",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					principalTypeValidator(),
+				},
+			},
+			"access_right": schema.StringAttribute{
+				MarkdownDescription: "The access right of the principal, `Admin`, `Member`, `Contributor` or `Viewer`",
 				Required:            true,
+				Validators: []validator.String{
+					accessRightValidator(),
+				},
 			},
 		},
 	}
 }
 
-// Update implements resource.ResourceWithConfigure.
+// Update changes the access right of the principal in place.
 func (r *WorkspacePermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	panic("unimplemented")
+	var plan models.WorkspacePermissionAssignment
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Changing the access of %s to workspace %s to %s", plan.Principal(), plan.WorkspaceId.ValueString(), plan.AccessRight.ValueString()))
+	err := r.client.UpdateGroupUser(ctx, plan.WorkspaceId.ValueString(), groupUserRequest(plan.EmailAddress, plan.Identifier, plan.PrincipalType, plan.AccessRight))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot change the access of %s to workspace with Id %s", plan.Principal(), plan.WorkspaceId.ValueString()), err.Error())
+		return
+	}
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// validatePrincipalConfig checks the principal against the rules of the client, before any request is sent:
+// a user is given by its email address, and a group or a service principal by its identifier.
+// The attributes are relative to parent, the root of the configuration or a nested object.
+func validatePrincipalConfig(parent path.Path, principalType types.String, emailAddress types.String, identifier types.String, diags *diag.Diagnostics) {
+	// The principal type may only be known at apply time.
+	if principalType.IsUnknown() || principalType.IsNull() {
+		return
+	}
+
+	user := &pbiModels.GroupUser{
+		EmailAddress:  principalConfigValue(emailAddress),
+		Identifier:    principalConfigValue(identifier),
+		PrincipalType: pbiModels.PrincipalType(principalType.ValueString()),
+	}
+	_, err := user.Principal()
+
+	var userErr *pbiModels.GroupUserError
+	if !errors.As(err, &userErr) {
+		return
+	}
+
+	detail := userErr.Error()
+	switch user.PrincipalType {
+	case pbiModels.PrincipalTypeUser:
+		detail = "a user is given by 'email_address', 'identifier' must not be set"
+	case pbiModels.PrincipalTypeGroup, pbiModels.PrincipalTypeApp:
+		detail = "a group or a service principal is given by 'identifier', 'email_address' must not be set"
+	}
+
+	diags.AddAttributeError(parent.AtName(groupUserAttributes[userErr.Property]), "Invalid attribute configuration", detail)
+}
+
+// groupUserAttributes maps the properties of a group user to the attributes holding them.
+var groupUserAttributes = map[string]string{
+	"emailAddress":         "email_address",
+	"groupUserAccessRight": "access_right",
+	"identifier":           "identifier",
+	"principalType":        "principal_type",
+}
+
+// principalConfigValue returns the value of a principal attribute for validation, an unknown value being a set one.
+func principalConfigValue(value types.String) string {
+	if value.IsUnknown() {
+		return "(known after apply)"
+	}
+	return value.ValueString()
+}
+
+// accessRightValidator checks that an attribute is an access right which can be granted on a workspace.
+func accessRightValidator() validator.String {
+	values := make([]string, len(pbiModels.GrantableGroupUserAccessRights))
+	for i, v := range pbiModels.GrantableGroupUserAccessRights {
+		values[i] = string(v)
+	}
+	return stringvalidator.OneOf(values...)
+}
+
+// principalTypeValidator checks that an attribute is a principal type which can be assigned to a workspace.
+func principalTypeValidator() validator.String {
+	values := make([]string, len(pbiModels.AssignablePrincipalTypes))
+	for i, v := range pbiModels.AssignablePrincipalTypes {
+		values[i] = string(v)
+	}
+	return stringvalidator.OneOf(values...)
+}
+
+// groupUserRequest returns the request granting a principal an access right.
+func groupUserRequest(emailAddress types.String, identifier types.String, principalType types.String, accessRight types.String) *pbiModels.GroupUser {
+	return &pbiModels.GroupUser{
+		EmailAddress:         emailAddress.ValueString(),
+		Identifier:           identifier.ValueString(),
+		GroupUserAccessRight: pbiModels.GroupUserAccessRight(accessRight.ValueString()),
+		PrincipalType:        pbiModels.PrincipalType(principalType.ValueString()),
+	}
+}
+
+// resolvedPrincipalType returns the type of the principal given by an attribute resolved through Microsoft Graph,
+// and the name of this attribute, empty when the principal is given by email address or identifier.
+func resolvedPrincipalType(data models.WorkspacePermissionAssignment) (pbiModels.PrincipalType, string) {
+	switch {
+	case !data.UserPrincipalName.IsNull():
+		return pbiModels.PrincipalTypeUser, "user_principal_name"
+	case !data.GroupDisplayName.IsNull():
+		return pbiModels.PrincipalTypeGroup, "group_display_name"
+	case !data.ApplicationId.IsNull():
+		return pbiModels.PrincipalTypeApp, "application_id"
+	default:
+		return "", ""
+	}
+}
+
+// resolvePrincipal returns the email address of the user, or the object id of the group or the service principal,
+// given by an attribute resolved through Microsoft Graph. It is unknown until the attribute and the provider are known.
+func (r *WorkspacePermissionResource) resolvePrincipal(ctx context.Context, data models.WorkspacePermissionAssignment) (types.String, error) {
+	if r.client == nil || data.UserPrincipalName.IsUnknown() || data.GroupDisplayName.IsUnknown() || data.ApplicationId.IsUnknown() {
+		return types.StringUnknown(), nil
+	}
+
+	switch {
+	case !data.UserPrincipalName.IsNull():
+		tflog.Debug(ctx, fmt.Sprintf("Resolving user %s through Microsoft Graph", data.UserPrincipalName.ValueString()))
+		user, err := r.client.GetGraphUser(ctx, data.UserPrincipalName.ValueString())
+		if powerbiapi.IsNotFound(err) {
+			return types.StringNull(), fmt.Errorf("no user has the user principal name %s", data.UserPrincipalName.ValueString())
+		}
+		if err != nil {
+			return types.StringNull(), err
+		}
+		return types.StringValue(user.UserPrincipalName), nil
+
+	case !data.GroupDisplayName.IsNull():
+		name := data.GroupDisplayName.ValueString()
+		tflog.Debug(ctx, fmt.Sprintf("Resolving group %s through Microsoft Graph", name))
+		groups, err := r.client.ListGraphGroups(ctx, fmt.Sprintf("displayName eq '%s'", strings.ReplaceAll(name, "'", "''")))
+		if err != nil {
+			return types.StringNull(), err
+		}
+		switch len(groups.Value) {
+		case 0:
+			return types.StringNull(), fmt.Errorf("no group is named %s", name)
+		case 1:
+			return types.StringValue(groups.Value[0].Id), nil
+		default:
+			return types.StringNull(), fmt.Errorf("%d groups are named %s, set 'identifier' to the object id of the group instead", len(groups.Value), name)
+		}
+
+	default:
+		appId := data.ApplicationId.ValueString()
+		tflog.Debug(ctx, fmt.Sprintf("Resolving the service principal of application %s through Microsoft Graph", appId))
+		servicePrincipals, err := r.client.ListGraphServicePrincipals(ctx, fmt.Sprintf("appId eq '%s'", strings.ReplaceAll(appId, "'", "''")))
+		if err != nil {
+			return types.StringNull(), err
+		}
+		if len(servicePrincipals.Value) == 0 {
+			return types.StringNull(), fmt.Errorf("no service principal has the application id %s", appId)
+		}
+		return types.StringValue(servicePrincipals.Value[0].Id), nil
+	}
+}
+
+// samePrincipal tells whether two email addresses or identifiers are known and equal ignoring the case, or both null.
+func samePrincipal(a types.String, b types.String) bool {
+	if a.IsUnknown() || b.IsUnknown() || a.IsNull() != b.IsNull() {
+		return false
+	}
+	return strings.EqualFold(a.ValueString(), b.ValueString())
+}
+
+// findGroupUser returns the user of the workspace whose identifier or email address is principal, compared ignoring case,
+// or nil when the principal has no access to the workspace.
+func findGroupUser(ctx context.Context, client *powerbiapi.Client, workspaceId string, principal string) (*pbiModels.GroupUser, error) {
+	pager := client.NewGroupUsersPager(workspaceId, nil)
+	for pager.More() {
+		users, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users.Value {
+			if strings.EqualFold(user.Identifier, principal) || strings.EqualFold(user.EmailAddress, principal) {
+				return &user, nil
+			}
+		}
+	}
+
+	return nil, nil
 }
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspacePermissionResource grants a user access to a workspace, imports the access, changes the access right
+// in place, grants it again when it is revoked in the portal, and revokes it, leaving the other users untouched.
+func TestAccWorkspacePermissionResource(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-permission"})
+	server.PutGroupUser(group.Id, fake.GroupUser{Identifier: "jane.doe@contoso.com", EmailAddress: "jane.doe@contoso.com", GroupUserAccessRight: "Admin", PrincipalType: "User"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", ""),
+			testAccCheckWorkspacePermission(server, group.Id, "jane.doe@contoso.com", "Admin"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWorkspacePermissionResourceUserConfig(server, group.Id, "Owner"),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config:      testAccWorkspacePermissionResourceUserConfig(server, group.Id, "None"),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: testAccWorkspacePermissionResourceUserConfig(server, group.Id, "Viewer"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace_permission.test", "id", group.Id+"/john.doe@contoso.com"),
+					testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", "Viewer"),
+				),
+			},
+			{
+				ResourceName:      "powerbi_workspace_permission.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccWorkspacePermissionResourceUserConfig(server, group.Id, "Member"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_permission.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", "Member"),
+			},
+			// Drift testing: the access is revoked outside of Terraform, the next apply grants it again.
+			{
+				PreConfig: func() {
+					server.RemoveGroupUser(group.Id, "john.doe@contoso.com")
+				},
+				Config: testAccWorkspacePermissionResourceUserConfig(server, group.Id, "Member"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_permission.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", "Member"),
+			},
+		},
+	})
+}
+
+// TestAccWorkspacePermissionResource_group grants a security group access to a workspace, and rejects a group given by email address.
+func TestAccWorkspacePermissionResource_group(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-permission"})
+	principal := "796131c3-8d85-44e1-bdfc-88ad8ba46520"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspacePermission(server, group.Id, principal, ""),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_permission" "test" {
+  workspace_id   = %q
+  email_address  = "sales@contoso.com"
+  principal_type = "Group"
+  access_right   = "Contributor"
+}
+`, group.Id),
+				ExpectError: regexp.MustCompile(`a group or a service principal is given by 'identifier'`),
+			},
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_permission" "test" {
+  workspace_id   = %q
+  identifier     = %q
+  principal_type = "User"
+  access_right   = "Contributor"
+}
+`, group.Id, principal),
+				ExpectError: regexp.MustCompile(`a user is given by 'email_address'`),
+			},
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_permission" "test" {
+  workspace_id   = %q
+  identifier     = %q
+  principal_type = "Group"
+  access_right   = "Contributor"
+}
+`, group.Id, principal),
+				Check: testAccCheckWorkspacePermission(server, group.Id, principal, "Contributor"),
+			},
+			{
+				ResourceName:      "powerbi_workspace_permission.test",
+				ImportState:       true,
+				ImportStateId:     group.Id + "/" + principal,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestAccWorkspacePermissionResource_graph grants access to principals given by user principal name, group name and
+// application id, resolved through Microsoft Graph at plan time, and replaces the access when the group name resolves to another group.
+func TestAccWorkspacePermissionResource_graph(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-permission"})
+	server.PutGraphUser(fake.GraphUser{UserPrincipalName: "john.doe@contoso.com"})
+	sales := server.PutGraphGroup(fake.GraphGroup{DisplayName: "Sales"})
+	salesTeam := server.PutGraphGroup(fake.GraphGroup{DisplayName: "Sales Team"})
+	app := server.PutGraphServicePrincipal(fake.GraphServicePrincipal{DisplayName: "reporting"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", ""),
+			testAccCheckWorkspacePermission(server, group.Id, sales.Id, ""),
+			testAccCheckWorkspacePermission(server, group.Id, salesTeam.Id, ""),
+			testAccCheckWorkspacePermission(server, group.Id, app.Id, ""),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkspacePermissionResourceGraphConfig(server, group.Id, "Sales", app.AppId),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						testAccExpectKnownValues("powerbi_workspace_permission.user", "email_address", "principal_type"),
+						testAccExpectKnownValues("powerbi_workspace_permission.group", "identifier", "principal_type"),
+						testAccExpectKnownValues("powerbi_workspace_permission.app", "identifier", "principal_type"),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace_permission.user", "email_address", "john.doe@contoso.com"),
+					resource.TestCheckResourceAttr("powerbi_workspace_permission.user", "principal_type", "User"),
+					resource.TestCheckResourceAttr("powerbi_workspace_permission.group", "identifier", sales.Id),
+					resource.TestCheckResourceAttr("powerbi_workspace_permission.group", "principal_type", "Group"),
+					resource.TestCheckResourceAttr("powerbi_workspace_permission.app", "identifier", app.Id),
+					resource.TestCheckResourceAttr("powerbi_workspace_permission.app", "principal_type", "App"),
+					testAccCheckWorkspacePermission(server, group.Id, "john.doe@contoso.com", "Viewer"),
+					testAccCheckWorkspacePermission(server, group.Id, sales.Id, "Viewer"),
+					testAccCheckWorkspacePermission(server, group.Id, app.Id, "Viewer"),
+				),
+			},
+			{
+				Config: testAccWorkspacePermissionResourceGraphConfig(server, group.Id, "Sales Team", app.AppId),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_permission.group", plancheck.ResourceActionDestroyBeforeCreate),
+						plancheck.ExpectResourceAction("powerbi_workspace_permission.user", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckWorkspacePermission(server, group.Id, sales.Id, ""),
+					testAccCheckWorkspacePermission(server, group.Id, salesTeam.Id, "Viewer"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspacePermissionResource_graphErrors rejects the principals Microsoft Graph cannot resolve to a single principal,
+// the conflicting attributes, and the resolution without Microsoft Graph.
+func TestAccWorkspacePermissionResource_graphErrors(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-permission"})
+	server.PutGraphGroup(fake.GraphGroup{DisplayName: "Sales"})
+	server.PutGraphGroup(fake.GraphGroup{DisplayName: "Sales"})
+
+	groupConfig := func(attributes string) string {
+		return fmt.Sprintf(`
+resource "powerbi_workspace_permission" "test" {
+  workspace_id = %q
+  access_right = "Viewer"
+%s
+}
+`, group.Id, attributes)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig(server) + groupConfig(`group_display_name = "Marketing"`),
+				ExpectError: regexp.MustCompile(`no group is named Marketing`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + groupConfig(`group_display_name = "Sales"`),
+				ExpectError: regexp.MustCompile(`2 groups are named Sales`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + groupConfig(`user_principal_name = "jane.doe@contoso.com"`),
+				ExpectError: regexp.MustCompile(`no user has the user principal name jane.doe@contoso.com`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + groupConfig(`application_id = "f089354e-8366-4e18-aea3-4cb4a3a50b48"`),
+				ExpectError: regexp.MustCompile(`no service principal has the application id`),
+			},
+			{
+				Config: testAccProviderConfig(server) + groupConfig(`group_display_name = "Sales"
+  principal_type     = "App"`),
+				ExpectError: regexp.MustCompile(`gives a principal of type Group`),
+			},
+			{
+				Config: testAccProviderConfig(server) + groupConfig(`group_display_name = "Sales"
+  identifier         = "796131c3-8d85-44e1-bdfc-88ad8ba46520"`),
+				ExpectError: regexp.MustCompile(`exactly one of 'email_address', 'identifier'`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + groupConfig(`identifier = "796131c3-8d85-44e1-bdfc-88ad8ba46520"`),
+				ExpectError: regexp.MustCompile(`'principal_type' is required with 'email_address' or 'identifier'`),
+			},
+			{
+				Config:      testAccProviderConfigWithoutFabric(server) + groupConfig(`group_display_name = "Marketing"`),
+				ExpectError: regexp.MustCompile(`set 'graph_base_url' in`),
+			},
+		},
+	})
+}
+
+// testAccWorkspacePermissionResourceUserConfig returns the configuration granting john.doe@contoso.com an access right to the workspace.
+func testAccWorkspacePermissionResourceUserConfig(server *fake.Server, workspaceId string, accessRight string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_permission" "test" {
+  workspace_id   = %q
+  email_address  = "john.doe@contoso.com"
+  principal_type = "User"
+  access_right   = %q
+}
+`, workspaceId, accessRight)
+}
+
+// testAccCheckWorkspacePermission checks the access right of a principal to the workspace, no access when accessRight is empty.
+func testAccCheckWorkspacePermission(server *fake.Server, workspaceId string, principal string, accessRight string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		for _, user := range server.GroupUsers(workspaceId) {
+			if user.Identifier != principal {
+				continue
+			}
+			if user.GroupUserAccessRight != accessRight {
+				return fmt.Errorf("%s has %q access to workspace %s, expected %q", principal, user.GroupUserAccessRight, workspaceId, accessRight)
+			}
+			return nil
+		}
+		if accessRight != "" {
+			return fmt.Errorf("%s has no access to workspace %s, expected %q", principal, workspaceId, accessRight)
+		}
+		return nil
+	}
+}
+
+// testAccWorkspacePermissionResourceGraphConfig returns the configuration granting viewer access to john.doe@contoso.com,
+// the group with the given name and the service principal of the given application, all resolved through Microsoft Graph.
+func testAccWorkspacePermissionResourceGraphConfig(server *fake.Server, workspaceId string, groupName string, appId string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_permission" "user" {
+  workspace_id        = %[1]q
+  user_principal_name = "John.Doe@contoso.com"
+  access_right        = "Viewer"
+}
+
+resource "powerbi_workspace_permission" "group" {
+  workspace_id       = %[1]q
+  group_display_name = %[2]q
+  access_right       = "Viewer"
+}
+
+resource "powerbi_workspace_permission" "app" {
+  workspace_id   = %[1]q
+  application_id = %[3]q
+  principal_type = "App"
+  access_right   = "Viewer"
+}
+`, workspaceId, groupName, appId)
+}
@@ -3,14 +3,18 @@ package provider
 import (
 	"context"
 	"fmt"
-	"terraform-provider-powerbi/internal/powerbiapi"
-	pbiModels "terraform-provider-powerbi/internal/powerbiapi/models"
-	"terraform-provider-powerbi/internal/provider/models"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
 )
 
 var (
@@ -38,7 +42,8 @@ func (d *WorkspacePermissionsDataSource) Metadata(_ context.Context, req datasou
 func (d *WorkspacePermissionsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Power BI workspace permissions data source",
+		MarkdownDescription: "Power BI workspace permissions data source, listing the permissions of a workspace that match all the configured filters. " +
+			"With `use_admin_api`, the permissions are read through the admin API, which also returns the identifiers of the principals in Microsoft Graph: the provider must then authenticate as a Fabric administrator, who needs no access to the workspace",
 
 		Attributes: map[string]schema.Attribute{
 			"workspace_id": schema.StringAttribute{
@@ -51,6 +56,36 @@ func (d *WorkspacePermissionsDataSource) Schema(_ context.Context, req datasourc
 				Optional:            true,
 				Computed:            true,
 			},
+			"principal_type": schema.StringAttribute{
+				MarkdownDescription: "Only list the permissions of this principal type, `User`, `Group`, `App` or `None`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(pbiModels.PrincipalTypeUser),
+						string(pbiModels.PrincipalTypeGroup),
+						string(pbiModels.PrincipalTypeApp),
+						string(pbiModels.PrincipalTypeNone),
+					),
+				},
+			},
+			"access_right": schema.StringAttribute{
+				MarkdownDescription: "Only list the permissions with this access right, `Admin`, `Member`, `Contributor` or `Viewer`",
+				Optional:            true,
+				Validators: []validator.String{
+					accessRightValidator(),
+				},
+			},
+			"user_type": schema.StringAttribute{
+				MarkdownDescription: "Only list the permissions of this user type, such as `Member` or `Guest`, ignoring the case",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"use_admin_api": schema.BoolAttribute{
+				MarkdownDescription: "Read the permissions through the admin API, which also returns `graph_id`. Default to false",
+				Optional:            true,
+			},
 			"permissions": schema.ListNestedAttribute{
 				MarkdownDescription: "The permissions of the workspace",
 				Computed:            true,
@@ -65,7 +100,7 @@ func (d *WorkspacePermissionsDataSource) Schema(_ context.Context, req datasourc
 							Computed:            true,
 						},
 						"graph_id": schema.StringAttribute{
-							MarkdownDescription: "Identifier of the principal in Microsoft Graph",
+							MarkdownDescription: "Identifier of the principal in Microsoft Graph. Only set when `use_admin_api` is true",
 							Computed:            true,
 						},
 						"access_right": schema.StringAttribute{
@@ -186,26 +221,42 @@ func (d *WorkspacePermissionsDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 
+	if data.UseAdminApi.ValueBool() {
+		// The admin API gives access to any workspace, whose users are retrieved without being one of them.
+		workspace, err = findWorkspaceAsAdmin(ctx, d.client, data)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot retrieve workspace through the admin API", err.Error())
+			return
+		}
+
+		workspaceUsers, err = d.client.GetGroupUsersAsAdmin(ctx, workspace.Id)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve permissions for workspace with Id %s", workspace.Id), err.Error())
+			return
+		}
+	}
+
 	// If the workspace id is set, retrieve the workspace and its users by id
-	if !data.WorkspaceId.IsNull() {
+	if !data.UseAdminApi.ValueBool() && !data.WorkspaceId.IsNull() {
 
-		workspace, err = d.client.GetGroup(data.WorkspaceId.ValueString())
+		workspace, err = d.client.GetGroup(ctx, data.WorkspaceId.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with Id %s", data.WorkspaceId.ValueString()), err.Error())
 			return
 		}
 
-		workspaceUsers, err = d.client.GetGroupUsers(data.WorkspaceId.ValueString())
+		users, err := listGroupUsers(ctx, d.client, data.WorkspaceId.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve permissions for workspace with Id %s", data.WorkspaceId.ValueString()), err.Error())
 			return
 		}
+		workspaceUsers = &pbiModels.GroupUsers{Value: users}
 	}
 
 	// If the workspace name is set, retrieve the workspace and its users by name.
 	// It relies on the GetGroups method to retrieve the workspace by name, and then retrieves the workspace and its users by id.
-	if !data.WorkspaceName.IsNull() {
-		workspaces, err := d.client.GetGroups(fmt.Sprintf("name eq '%s'", data.WorkspaceName.ValueString()), 0, 0)
+	if !data.UseAdminApi.ValueBool() && !data.WorkspaceName.IsNull() {
+		workspaces, err := d.client.GetGroups(ctx, fmt.Sprintf("name eq '%s'", strings.ReplaceAll(data.WorkspaceName.ValueString(), "'", "''")), 0, 0)
 
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with name %s", data.WorkspaceName.ValueString()), err.Error())
@@ -222,18 +273,19 @@ func (d *WorkspacePermissionsDataSource) Read(ctx context.Context, req datasourc
 			return
 		}
 
-		workspace, err = d.client.GetGroup(workspaces.Value[0].Id)
+		workspace, err = d.client.GetGroup(ctx, workspaces.Value[0].Id)
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with Id %s", data.WorkspaceId.ValueString()), err.Error())
 			return
 		}
 
-		workspaceUsers, err = d.client.GetGroupUsers(workspaces.Value[0].Id)
+		users, err := listGroupUsers(ctx, d.client, workspaces.Value[0].Id)
 
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve permissions for workspace %s", data.WorkspaceName.ValueString()), err.Error())
 			return
 		}
+		workspaceUsers = &pbiModels.GroupUsers{Value: users}
 	}
 
 	if workspaceUsers == nil {
@@ -242,11 +294,15 @@ func (d *WorkspacePermissionsDataSource) Read(ctx context.Context, req datasourc
 	}
 
 	// Loop on the workspace users and populate the data
-	var permissions []models.WorkspacePermission
+	permissions := []models.WorkspacePermission{}
 
 	for _, user := range workspaceUsers.Value {
 		var permission models.WorkspacePermission
 
+		if !permissionMatches(data, user) {
+			continue
+		}
+
 		permission.DisplayName = types.StringValue(user.DisplayName)
 
 		if user.EmailAddress != "" {
@@ -311,3 +367,48 @@ func (d *WorkspacePermissionsDataSource) Read(ctx context.Context, req datasourc
 	}
 
 }
+
+// findWorkspaceAsAdmin returns the workspace with the configured id or name through the admin API, ignoring the deleted workspaces.
+func findWorkspaceAsAdmin(ctx context.Context, client *powerbiapi.Client, data models.WorkspacePermissionsData) (*pbiModels.Group, error) {
+	filter := fmt.Sprintf("id eq '%s'", strings.ReplaceAll(data.WorkspaceId.ValueString(), "'", "''"))
+	if !data.WorkspaceName.IsNull() {
+		filter = fmt.Sprintf("name eq '%s'", strings.ReplaceAll(data.WorkspaceName.ValueString(), "'", "''"))
+	}
+
+	var found []pbiModels.Group
+	pager := client.NewAdminGroupsPager(&powerbiapi.AdminGroupsPagerOptions{Filter: filter})
+	for pager.More() {
+		groups, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups.Value {
+			if group.State != pbiModels.GroupStateDeleted {
+				found = append(found, group)
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no workspace matches %s", filter)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("%d workspaces match %s", len(found), filter)
+	}
+}
+
+// permissionMatches tells whether the permission matches the principal type, access right and user type filters of the data source.
+func permissionMatches(data models.WorkspacePermissionsData, user pbiModels.GroupUser) bool {
+	if !data.PrincipalType.IsNull() && data.PrincipalType.ValueString() != string(user.PrincipalType) {
+		return false
+	}
+	if !data.AccessRight.IsNull() && data.AccessRight.ValueString() != string(user.GroupUserAccessRight) {
+		return false
+	}
+	if !data.UserType.IsNull() && !strings.EqualFold(data.UserType.ValueString(), user.UserType) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspacePermissionsDataSource lists the principals of a workspace, looked up by id and by name.
+func TestAccWorkspacePermissionsDataSource(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-permissions"})
+	server.PutGroupUser(group.Id, fake.GroupUser{
+		DisplayName:          "John Doe",
+		EmailAddress:         "john.doe@example.com",
+		GroupUserAccessRight: "Admin",
+		Identifier:           "john.doe@example.com",
+		PrincipalType:        "User",
+	})
+	server.PutGroupUser(group.Id, fake.GroupUser{
+		DisplayName:          "Readers",
+		GroupUserAccessRight: "Viewer",
+		Identifier:           "796131c3-8d85-44e1-bdfc-88ad8ba46520",
+		PrincipalType:        "Group",
+	})
+	quoted := server.PutGroup(fake.Group{Name: "tf-acc-permissions-o'brien"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read by id
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_permissions" "test" {
+  workspace_id = "` + group.Id + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "workspace_name", "tf-acc-permissions"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.0.email_address", "john.doe@example.com"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.0.access_right", "Admin"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.1.principal_type", "Group"),
+					resource.TestCheckNoResourceAttr("data.powerbi_workspace_permissions.test", "permissions.1.email_address"),
+				),
+			},
+			// Read by name
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_permissions" "test" {
+  workspace_name = "tf-acc-permissions"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "workspace_id", group.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.1.identifier", "796131c3-8d85-44e1-bdfc-88ad8ba46520"),
+				),
+			},
+			// Read by a name with a quote, escaped in the filter
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_permissions" "test" {
+  workspace_name = "tf-acc-permissions-o'brien"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "workspace_id", quoted.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspacePermissionsDataSource_filters lists the permissions matching the principal type, access right and user type filters.
+func TestAccWorkspacePermissionsDataSource_filters(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-permissions-filters"})
+	server.PutGroupUser(group.Id, fake.GroupUser{
+		DisplayName:          "John Doe",
+		EmailAddress:         "john.doe@example.com",
+		GroupUserAccessRight: "Admin",
+		Identifier:           "john.doe@example.com",
+		PrincipalType:        "User",
+		UserType:             "Member",
+	})
+	server.PutGroupUser(group.Id, fake.GroupUser{
+		DisplayName:          "Jane Guest",
+		EmailAddress:         "jane.guest@example.org",
+		GroupUserAccessRight: "Viewer",
+		Identifier:           "jane.guest@example.org",
+		PrincipalType:        "User",
+		UserType:             "Guest",
+	})
+	server.PutGroupUser(group.Id, fake.GroupUser{
+		DisplayName:          "Readers",
+		GroupUserAccessRight: "Viewer",
+		Identifier:           "796131c3-8d85-44e1-bdfc-88ad8ba46520",
+		PrincipalType:        "Group",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Filter on the principal type
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_permissions" "test" {
+  workspace_id   = "` + group.Id + `"
+  principal_type = "User"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.0.email_address", "john.doe@example.com"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.1.email_address", "jane.guest@example.org"),
+				),
+			},
+			// Filter on the access right and the user type, ignoring the case of the latter
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_permissions" "test" {
+  workspace_id = "` + group.Id + `"
+  access_right = "Viewer"
+  user_type    = "guest"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.0.display_name", "Jane Guest"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.0.user_type", "Guest"),
+				),
+			},
+			// No permission matches the filters
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_permissions" "test" {
+  workspace_id   = "` + group.Id + `"
+  principal_type = "App"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.#", "0"),
+			},
+		},
+	})
+}
+
+// TestAccWorkspacePermissionsDataSource_adminApi lists the permissions of a workspace through the admin API, which returns the Graph ids.
+func TestAccWorkspacePermissionsDataSource_adminApi(t *testing.T) {
+	server := testAccServer(t)
+	group := server.PutGroup(fake.Group{Name: "tf-acc-permissions-admin"})
+	server.PutGroupUser(group.Id, fake.GroupUser{
+		DisplayName:          "John Doe",
+		EmailAddress:         "john.doe@example.com",
+		GraphId:              "0c5b7a4e-3f0e-4b43-8f43-7d6c3a3f1e2a",
+		GroupUserAccessRight: "Admin",
+		Identifier:           "john.doe@example.com",
+		PrincipalType:        "User",
+		UserType:             "Member",
+	})
+	server.PutGroupUser(group.Id, fake.GroupUser{
+		DisplayName:          "Readers",
+		GroupUserAccessRight: "Viewer",
+		Identifier:           "796131c3-8d85-44e1-bdfc-88ad8ba46520",
+		PrincipalType:        "Group",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read by id
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_permissions" "test" {
+  workspace_id  = "` + group.Id + `"
+  use_admin_api = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "workspace_name", "tf-acc-permissions-admin"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.0.graph_id", "0c5b7a4e-3f0e-4b43-8f43-7d6c3a3f1e2a"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.1.graph_id", "796131c3-8d85-44e1-bdfc-88ad8ba46520"),
+				),
+			},
+			// Read by name, with a filter
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspace_permissions" "test" {
+  workspace_name = "tf-acc-permissions-admin"
+  principal_type = "Group"
+  use_admin_api  = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "workspace_id", group.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace_permissions.test", "permissions.0.display_name", "Readers"),
+				),
+			},
+		},
+	})
+}
@@ -2,20 +2,52 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"terraform-provider-powerbi/internal/powerbiapi"
-	pbiModels "terraform-provider-powerbi/internal/powerbiapi/models"
-	"terraform-provider-powerbi/internal/provider/models"
-
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
 )
 
 var _ resource.Resource = &WorkspaceResource{}                // Ensure that WorkspaceResource implements the Resource interface.
 var _ resource.ResourceWithImportState = &WorkspaceResource{} // Ensure that WorkspaceResource implements the ResourceWithImportState interface.
 var _ resource.ResourceWithConfigure = &WorkspaceResource{}   // Ensure that WorkspaceResource implements the ResourceWithConfigure interface.
+var _ resource.ResourceWithModifyPlan = &WorkspaceResource{}  // Ensure that WorkspaceResource implements the ResourceWithModifyPlan interface.
+
+// workspaceNameMaxLength is the maximum length of a workspace name.
+const workspaceNameMaxLength = 256
+
+// workspaceNamePattern matches the valid workspace names: the service trims the leading and trailing spaces,
+// which would then differ from the configuration, and rejects control characters.
+var workspaceNamePattern = regexp.MustCompile(`^[^\s\p{Cc}](?:[^\p{Cc}]*[^\s\p{Cc}])?$`)
+
+// workspaceImportNamePrefix is the prefix of the import IDs which are workspace names rather than workspace IDs.
+const workspaceImportNamePrefix = "name:"
+
+// capacityAssignmentTimeout bounds the wait for the assignment of a workspace to a capacity.
+const capacityAssignmentTimeout = 10 * time.Minute
+
+// capacityAssignmentPollInterval is the delay between two requests of the capacity assignment status.
+// It is a variable so that the acceptance tests against the fake service do not wait.
+var capacityAssignmentPollInterval = 5 * time.Second
 
 // NewWorkspaceResource is a function that creates a new instance of the WorkspaceResource.
 func NewWorkspaceResource() resource.Resource {
@@ -53,6 +85,7 @@ func (r *WorkspaceResource) Create(ctx context.Context, req resource.CreateReque
 	var config models.Workspace
 	var state models.Workspace
 	var workspace *pbiModels.Group
+	var adopted bool
 	var err error
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
@@ -61,21 +94,109 @@ func (r *WorkspaceResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	// The resource settings are not part of the workspace, they default to false when unset.
+	state.AdoptExisting = types.BoolValue(config.AdoptExisting.ValueBool())
+	state.ForceDestroy = types.BoolValue(config.ForceDestroy.ValueBool())
+	state.Description = config.Description
+	state.CapacityId = config.CapacityId
+
 	tflog.Debug(ctx, fmt.Sprintf("Creating workspace with name: %s", config.Name.ValueString()))
 
-	workspace, err = r.client.CreateGroup(config.Name.ValueString())
+	workspace, err = r.client.CreateGroup(ctx, config.Name.ValueString())
 
-	if err != nil {
+	if powerbiapi.IsAlreadyExists(err) && config.AdoptExisting.ValueBool() {
+		tflog.Debug(ctx, fmt.Sprintf("Adopting the existing workspace with name: %s", config.Name.ValueString()))
+		workspace, err = findWorkspaceByName(ctx, r.client, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot adopt workspace with name %s", config.Name.ValueString()), err.Error())
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Adopted existing workspace with name %s", config.Name.ValueString()),
+			fmt.Sprintf("A workspace named %q already exists, it is now managed by Terraform with Id %s. "+
+				"Its contents and users are kept, and it is deleted when the resource is destroyed.", workspace.Name, workspace.Id),
+		)
+		adopted = true
+	} else if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot create workspace with name %s", config.Name.ValueString()), err.Error())
 		return
+	} else {
+		tflog.Debug(ctx, "Workspace created successfully")
+	}
+
+	// failConfiguration reports the failure of a step following the creation. A created workspace is kept in the state,
+	// Terraform taints it and replaces it on the next apply. An adopted workspace is left out of the state instead,
+	// the next apply adopts it again rather than destroying a workspace Terraform did not create.
+	failConfiguration := func(summary string, err error) {
+		if !adopted {
+			setWorkspaceState(&state, workspace)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
+		resp.Diagnostics.AddError(summary, err.Error())
+	}
+
+	if !config.Description.IsNull() {
+		tflog.Debug(ctx, fmt.Sprintf("Setting the description of workspace %s", workspace.Id))
+		err = r.client.UpdateGroupDescription(ctx, workspace.Id, config.Description.ValueString())
+		if err != nil {
+			failConfiguration(fmt.Sprintf("Cannot set the description of workspace with Id %s", workspace.Id), err)
+			return
+		}
+	}
+
+	if !config.CapacityId.IsNull() && !sameCapacity(config.CapacityId, types.StringValue(workspace.CapacityId)) {
+		err = r.assignCapacity(ctx, workspace.Id, config.CapacityId)
+		if err != nil {
+			failConfiguration(fmt.Sprintf("Cannot assign workspace with Id %s to capacity %s", workspace.Id, config.CapacityId.ValueString()), err)
+			return
+		}
+	}
+
+	updateRequest := &pbiModels.UpdateGroupRequest{}
+	if workspace.Name != config.Name.ValueString() {
+		// An adopted workspace may be named with another case, it is renamed as configured.
+		updateRequest.Name = config.Name.ValueString()
+	}
+	if !config.DefaultDatasetStorageFormat.IsNull() {
+		updateRequest.DefaultDatasetStorageFormat = pbiModels.DefaultDatasetStorageFormat(config.DefaultDatasetStorageFormat.ValueString())
+	}
+
+	if updateRequest.Name != "" || updateRequest.DefaultDatasetStorageFormat != "" {
+		tflog.Debug(ctx, fmt.Sprintf("Configuring workspace %s", workspace.Id))
+		err = r.client.UpdateGroup(ctx, workspace.Id, updateRequest)
+		if err != nil {
+			failConfiguration(fmt.Sprintf("Cannot update workspace with Id %s", workspace.Id), err)
+			return
+		}
+	}
+
+	if config.LogAnalytics != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Assigning a Log Analytics workspace to workspace %s", workspace.Id))
+		err = r.client.UpdateGroupLogAnalyticsWorkspaceAsAdmin(ctx, workspace.Id, logAnalyticsWorkspaceRequest(config.LogAnalytics))
+		if err != nil {
+			failConfiguration(fmt.Sprintf("Cannot assign a Log Analytics workspace to workspace with Id %s", workspace.Id), err)
+			return
+		}
+	}
+
+	if updateRequest.Name != "" || !config.CapacityId.IsNull() || !config.DefaultDatasetStorageFormat.IsNull() || config.LogAnalytics != nil {
+		id := workspace.Id
+		workspace, err = r.client.GetGroup(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with Id %s", id), err.Error())
+			return
+		}
 	}
-	tflog.Debug(ctx, "Workspace created successfully")
 
 	tflog.Debug(ctx, "Populate the response with the workspace data")
-	state.Id = types.StringValue(workspace.Id)
-	state.Name = types.StringValue(workspace.Name)
-	state.IsReadOnly = types.BoolValue(workspace.IsReadOnly)
-	state.IsOnDedicatedCapacity = types.BoolValue(workspace.IsOnDedicatedCapacity)
+	setWorkspaceState(&state, workspace)
+
+	err = readWorkspaceDescription(ctx, r.client, workspace.Id, &state.Description, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the description of workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -85,6 +206,7 @@ func (r *WorkspaceResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 // Delete deletes the Power BI workspace.
+// Unless force_destroy is set, it refuses to delete a workspace which still has contents.
 func (r *WorkspaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state models.Workspace
 	var err error
@@ -95,8 +217,33 @@ func (r *WorkspaceResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	if state.ForceDestroy.ValueBool() {
+		// The service refuses to delete a workspace assigned to a deployment pipeline stage.
+		err = r.unassignPipelineStages(ctx, state.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot delete workspace with Id %s", state.Id.ValueString()), err.Error())
+			return
+		}
+	} else {
+		contents, err := listWorkspaceContents(ctx, r.client, state.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot list the contents of workspace with Id %s", state.Id.ValueString()), err.Error())
+			return
+		}
+
+		if len(contents) > 0 {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Cannot delete workspace with Id %s", state.Id.ValueString()),
+				fmt.Sprintf("The workspace is not empty and deleting it would delete its contents:\n  - %s\n\n"+
+					"Remove the contents first, or set force_destroy to true to delete the workspace with its contents.",
+					strings.Join(contents, "\n  - ")),
+			)
+			return
+		}
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Deleting workspace with name: %s", state.Name.ValueString()))
-	err = r.client.DeleteGroup(state.Id.ValueString())
+	err = r.client.DeleteGroup(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot delete workspace with Id %s", state.Id.ValueString()), err.Error())
 		return
@@ -105,9 +252,45 @@ func (r *WorkspaceResource) Delete(ctx context.Context, req resource.DeleteReque
 	tflog.Debug(ctx, "Workspace deleted successfully")
 }
 
-// ImportState implements resource.ResourceWithImportState.
-func (r *WorkspaceResource) ImportState(_ context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	panic("unimplemented")
+// ImportState imports an existing workspace by its ID, or by its name with the "name:<workspace name>" syntax.
+func (r *WorkspaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var state models.Workspace
+	var workspace *pbiModels.Group
+	var err error
+
+	if name, ok := strings.CutPrefix(req.ID, workspaceImportNamePrefix); ok {
+		tflog.Debug(ctx, fmt.Sprintf("Importing workspace with name: %s", name))
+		workspace, err = findWorkspaceByName(ctx, r.client, name)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot import workspace with name %s", name), err.Error())
+			return
+		}
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Importing workspace with Id: %s", req.ID))
+		workspace, err = r.client.GetGroup(ctx, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot import workspace with Id %s", req.ID), err.Error())
+			return
+		}
+	}
+
+	setWorkspaceState(&state, workspace)
+	state.AdoptExisting = types.BoolValue(false)
+	state.ForceDestroy = types.BoolValue(false)
+
+	// The description is read once at import, an empty one is left unmanaged.
+	state.Description = types.StringUnknown()
+	err = readWorkspaceDescription(ctx, r.client, workspace.Id, &state.Description, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot import workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
+	if state.Description.ValueString() == "" {
+		state.Description = types.StringNull()
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Metadata sets the metadata for the WorkspaceResource.
@@ -115,6 +298,107 @@ func (r *WorkspaceResource) Metadata(_ context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_workspace"
 }
 
+// ModifyPlan keeps the capacity attributes known while the workspace stays on its capacity, whatever the case of its id,
+// and fails the plans the service would reject at apply time: the settings requiring a dedicated capacity on the shared
+// capacity, a description without the Fabric API, and a rename onto the name of another workspace.
+func (r *WorkspaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var config models.Workspace
+	var plan models.Workspace
+	var state models.Workspace
+
+	// Nothing to check when the workspace is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// AlsoRequires lets the empty GUID through, which moves the workspace to the shared capacity.
+	// An unknown capacity id is left to the service.
+	if !config.CapacityId.IsNull() && !config.CapacityId.IsUnknown() && sharedCapacityAsEmpty(config.CapacityId.ValueString()) == "" {
+		if !config.DefaultDatasetStorageFormat.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_dataset_storage_format"),
+				"Dedicated capacity required",
+				"The default dataset storage format can only be set on a workspace on a dedicated capacity, but capacity_id is the shared capacity.",
+			)
+		}
+		if config.LogAnalytics != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("log_analytics"),
+				"Dedicated capacity required",
+				"A Log Analytics workspace can only be assigned to a workspace on a dedicated capacity, but capacity_id is the shared capacity.",
+			)
+		}
+	}
+
+	// The description is set after the workspace is created, failing then would taint the new workspace.
+	// The provider is not configured yet when its attributes depend on other resources.
+	if r.client != nil && r.client.FabricURL == "" && !config.Description.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("description"),
+			"Fabric API not available",
+			"The description of a workspace is managed through the Fabric API, which is not used with a custom base_url or an access_token: set fabric_base_url in the provider configuration, or remove the description.",
+		)
+	}
+
+	// Nothing more to check when the workspace is created.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An unset capacity id keeps the workspace on its capacity, UseStateForUnknown leaves it unknown on the shared capacity.
+	if config.CapacityId.IsNull() {
+		plan.CapacityId = state.CapacityId
+	}
+
+	// Likewise an unset description is left unchanged, and stays null when it is not managed.
+	if config.Description.IsNull() {
+		plan.Description = state.Description
+	}
+
+	if sameCapacity(plan.CapacityId, state.CapacityId) {
+		if plan.IsOnDedicatedCapacity.IsUnknown() {
+			plan.IsOnDedicatedCapacity = state.IsOnDedicatedCapacity
+		}
+		if plan.DefaultDatasetStorageFormat.IsUnknown() {
+			plan.DefaultDatasetStorageFormat = state.DefaultDatasetStorageFormat
+		}
+	}
+
+	// The provider is not configured yet when its attributes depend on other resources.
+	if r.client != nil && !plan.Name.IsUnknown() && !plan.Name.Equal(state.Name) {
+		ids, err := listWorkspaceIdsByName(ctx, r.client, plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with name %s", plan.Name.ValueString()), err.Error())
+			return
+		}
+		for _, id := range ids {
+			if !strings.EqualFold(id, state.Id.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("name"),
+					"Workspace name already in use",
+					fmt.Sprintf("Workspace %s cannot be renamed to %q: workspace %s already has this name, workspace names are unique in the tenant.", state.Id.ValueString(), plan.Name.ValueString(), id),
+				)
+				return
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 // Read updates the state with the data from the Power BI service.
 func (r *WorkspaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state models.Workspace
@@ -128,16 +412,25 @@ func (r *WorkspaceResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Reading workspace with name: %s", state.Name.ValueString()))
-	workspace, err = r.client.GetGroup(state.Id.ValueString())
+	workspace, err = r.client.GetGroup(ctx, state.Id.ValueString())
+	if powerbiapi.IsNotFound(err) {
+		// The workspace was deleted outside of Terraform, removing it from the state plans its creation again.
+		tflog.Warn(ctx, fmt.Sprintf("Workspace with Id %s not found, removing it from the state", state.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with Id %s", state.Id.ValueString()), err.Error())
 		return
 	}
 
-	state.Id = types.StringValue(workspace.Id)
-	state.Name = types.StringValue(workspace.Name)
-	state.IsReadOnly = types.BoolValue(workspace.IsReadOnly)
-	state.IsOnDedicatedCapacity = types.BoolValue(workspace.IsOnDedicatedCapacity)
+	setWorkspaceState(&state, workspace)
+
+	err = readWorkspaceDescription(ctx, r.client, workspace.Id, &state.Description, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the description of workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -153,16 +446,92 @@ func (r *WorkspaceResource) Schema(_ context.Context, req resource.SchemaRequest
 		MarkdownDescription: "Power BI workspace resource",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the workspace",
+				MarkdownDescription: "The name of the workspace, unique in the tenant. It has at most 256 characters, does not start or end with a space and has no control characters",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, workspaceNameMaxLength),
+					stringvalidator.RegexMatches(workspaceNamePattern, "must not start or end with a space nor contain control characters"),
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The id of the workspace",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"capacity_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the Premium or Fabric capacity the workspace is assigned to, compared ignoring case. " +
+					"When unset, the workspace stays on its current capacity. Set it to the empty GUID `00000000-0000-0000-0000-000000000000` to move the workspace back to the shared capacity",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_dataset_storage_format": schema.StringAttribute{
+				MarkdownDescription: "The default dataset storage format of the workspace, `Small` or `Large`. It requires the workspace to be on a dedicated capacity, set with `capacity_id`",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(pbiModels.DefaultDatasetStorageFormatSmall),
+						string(pbiModels.DefaultDatasetStorageFormatLarge),
+					),
+					stringvalidator.AlsoRequires(path.MatchRoot("capacity_id")),
+				},
+			},
+			"log_analytics": schema.SingleNestedAttribute{
+				MarkdownDescription: "The Azure Log Analytics workspace the workspace sends its activity logs to. It requires the workspace to be on a dedicated capacity, set with `capacity_id`. It is assigned through the admin API, so the provider must authenticate as a Fabric administrator",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"subscription_id": schema.StringAttribute{
+						MarkdownDescription: "The Azure subscription of the Log Analytics workspace",
+						Required:            true,
+					},
+					"resource_group": schema.StringAttribute{
+						MarkdownDescription: "The resource group of the Log Analytics workspace",
+						Required:            true,
+					},
+					"resource_name": schema.StringAttribute{
+						MarkdownDescription: "The name of the Log Analytics workspace",
+						Required:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRoot("capacity_id")),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take an existing workspace with the same name under management instead of failing to create the workspace. It only applies at creation, with a warning when a workspace is adopted. When the configuration of an adopted workspace fails, it is left out of the state and adopted again on the next apply, instead of being replaced",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the workspace. It is managed through the Fabric API, see the `fabric_base_url` provider attribute, and left unchanged when unset. " +
+					"The Fabric API is only called for the workspaces with a description, and a warning is raised instead of an error when it denies access. Setting a description when the Fabric API is not used fails at plan time",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(4000),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the workspace with its reports, datasets, dashboards and dataflows, and to unassign it from its deployment pipeline stage, when the resource is destroyed. When `false`, the default, destroying a workspace which is not empty fails",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 			"is_read_only": schema.BoolAttribute{
 				MarkdownDescription: "Indicates whether the workspace is read-only",
 				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"is_on_dedicated_capacity": schema.BoolAttribute{
 				MarkdownDescription: "Indicates whether the workspace is on dedicated capacity",
@@ -188,31 +557,80 @@ func (r *WorkspaceResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	updateRequest = &pbiModels.UpdateGroupRequest{
-		Name: plan.Name.ValueString(),
+	// The Log Analytics workspace is unassigned before the workspace leaves its capacity.
+	if plan.LogAnalytics == nil && state.LogAnalytics != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unassigning the Log Analytics workspace of workspace %s", state.Id.ValueString()))
+		err = r.client.UpdateGroupLogAnalyticsWorkspaceAsAdmin(ctx, state.Id.ValueString(), nil)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot unassign the Log Analytics workspace of workspace with Id %s", state.Id.ValueString()), err.Error())
+			return
+		}
+	}
+
+	// The workspace moves to its capacity first, the default dataset storage format and
+	// the Log Analytics workspace require a dedicated capacity.
+	if !sameCapacity(plan.CapacityId, state.CapacityId) {
+		err = r.assignCapacity(ctx, state.Id.ValueString(), plan.CapacityId)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot assign workspace with Id %s to capacity %s", state.Id.ValueString(), plan.CapacityId.ValueString()), err.Error())
+			return
+		}
+	}
+
+	updateRequest = &pbiModels.UpdateGroupRequest{}
+	if !plan.Name.Equal(state.Name) {
+		updateRequest.Name = plan.Name.ValueString()
+	}
+	if !plan.DefaultDatasetStorageFormat.IsUnknown() && !plan.DefaultDatasetStorageFormat.IsNull() && !plan.DefaultDatasetStorageFormat.Equal(state.DefaultDatasetStorageFormat) {
+		updateRequest.DefaultDatasetStorageFormat = pbiModels.DefaultDatasetStorageFormat(plan.DefaultDatasetStorageFormat.ValueString())
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Updating workspace with name: %s", state.Name.ValueString()))
-	err = r.client.UpdateGroup(state.Id.ValueString(), updateRequest)
+	err = r.client.UpdateGroup(ctx, state.Id.ValueString(), updateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot update workspace with Id %s", state.Id.ValueString()), err.Error())
 		return
 	}
 
+	if plan.LogAnalytics != nil && !plan.LogAnalytics.Equal(state.LogAnalytics) {
+		tflog.Debug(ctx, fmt.Sprintf("Assigning a Log Analytics workspace to workspace %s", state.Id.ValueString()))
+		err = r.client.UpdateGroupLogAnalyticsWorkspaceAsAdmin(ctx, state.Id.ValueString(), logAnalyticsWorkspaceRequest(plan.LogAnalytics))
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot assign a Log Analytics workspace to workspace with Id %s", state.Id.ValueString()), err.Error())
+			return
+		}
+	}
+
+	if !plan.Description.IsUnknown() && !plan.Description.Equal(state.Description) {
+		tflog.Debug(ctx, fmt.Sprintf("Setting the description of workspace %s", state.Id.ValueString()))
+		err = r.client.UpdateGroupDescription(ctx, state.Id.ValueString(), plan.Description.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Cannot set the description of workspace with Id %s", state.Id.ValueString()), err.Error())
+			return
+		}
+	}
+
 	tflog.Debug(ctx, "Workspace updated successfully")
 
 	tflog.Debug(ctx, "Populate the response with the workspace data")
 	tflog.Debug(ctx, fmt.Sprintf("Reading workspace with name: %s", plan.Name.ValueString()))
-	workspace, err = r.client.GetGroup(state.Id.ValueString())
+	workspace, err = r.client.GetGroup(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve workspace with Id %s", state.Id.ValueString()), err.Error())
 		return
 	}
 
-	state.Id = types.StringValue(workspace.Id)
-	state.Name = types.StringValue(workspace.Name)
-	state.IsReadOnly = types.BoolValue(workspace.IsReadOnly)
-	state.IsOnDedicatedCapacity = types.BoolValue(workspace.IsOnDedicatedCapacity)
+	state.CapacityId = plan.CapacityId
+	setWorkspaceState(&state, workspace)
+	state.AdoptExisting = plan.AdoptExisting
+	state.ForceDestroy = plan.ForceDestroy
+	state.Description = plan.Description
+
+	err = readWorkspaceDescription(ctx, r.client, workspace.Id, &state.Description, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve the description of workspace with Id %s", workspace.Id), err.Error())
+		return
+	}
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -220,3 +638,218 @@ func (r *WorkspaceResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 }
+
+// setWorkspaceState populates the state with the workspace returned by the Power BI service.
+// The capacity id of the state is kept while it designates the capacity of the workspace, whatever its case.
+func setWorkspaceState(state *models.Workspace, workspace *pbiModels.Group) {
+	state.Id = types.StringValue(workspace.Id)
+	state.Name = types.StringValue(workspace.Name)
+	state.IsReadOnly = types.BoolValue(workspace.IsReadOnly)
+	state.IsOnDedicatedCapacity = types.BoolValue(workspace.IsOnDedicatedCapacity)
+	if state.CapacityId.IsUnknown() || !sameCapacity(state.CapacityId, types.StringValue(workspace.CapacityId)) {
+		state.CapacityId = optionalString(workspace.CapacityId)
+	}
+	if workspace.DefaultDatasetStorageFormat != "" {
+		state.DefaultDatasetStorageFormat = types.StringValue(string(workspace.DefaultDatasetStorageFormat))
+	} else {
+		state.DefaultDatasetStorageFormat = types.StringNull()
+	}
+	if workspace.LogAnalyticsWorkspace.ResourceName != "" {
+		state.LogAnalytics = &models.LogAnalyticsWorkspace{
+			SubscriptionId: types.StringValue(workspace.LogAnalyticsWorkspace.SubscriptionId),
+			ResourceGroup:  types.StringValue(workspace.LogAnalyticsWorkspace.ResourceGroup),
+			ResourceName:   types.StringValue(workspace.LogAnalyticsWorkspace.ResourceName),
+		}
+	} else {
+		state.LogAnalytics = nil
+	}
+}
+
+// readWorkspaceDescription reads the description of the workspace into description, unless it is null: the workspaces
+// which leave it unset never call the Fabric API.
+// When the Fabric API is not available, or denies access with a warning, description is kept as is, or set to null
+// when it is unknown, so credentials without access to the Fabric API do not break the existing workspaces.
+func readWorkspaceDescription(ctx context.Context, client *powerbiapi.Client, workspaceId string, description *types.String, diags *diag.Diagnostics) error {
+	if description.IsNull() {
+		return nil
+	}
+
+	value, err := client.GetGroupDescription(ctx, workspaceId)
+	switch {
+	case errors.Is(err, powerbiapi.ErrFabricUnavailable):
+		tflog.Debug(ctx, fmt.Sprintf("The Fabric API is not available, the description of workspace %s is not read", workspaceId))
+	case powerbiapi.IsUnauthorized(err):
+		diags.AddWarning(
+			fmt.Sprintf("Cannot retrieve the description of workspace with Id %s", workspaceId),
+			fmt.Sprintf("The Fabric API denied access, the description is left unchanged. "+
+				"Check that the provider credentials are allowed to call the Fabric API.\n\n%s", err.Error()),
+		)
+	case err != nil:
+		return err
+	default:
+		*description = types.StringValue(value)
+	}
+
+	if description.IsUnknown() {
+		*description = types.StringNull()
+	}
+	return nil
+}
+
+// logAnalyticsWorkspaceRequest returns the Log Analytics workspace to assign to a workspace.
+// Its id is left empty: the API requires the property, but the identifier is assigned by the service.
+func logAnalyticsWorkspaceRequest(logAnalytics *models.LogAnalyticsWorkspace) *pbiModels.AzureResource {
+	return &pbiModels.AzureResource{
+		SubscriptionId: logAnalytics.SubscriptionId.ValueString(),
+		ResourceGroup:  logAnalytics.ResourceGroup.ValueString(),
+		ResourceName:   logAnalytics.ResourceName.ValueString(),
+	}
+}
+
+// sameCapacity tells whether two capacity ids designate the same capacity. Ids are compared ignoring case,
+// and null, empty and the empty GUID all designate the shared capacity.
+func sameCapacity(a types.String, b types.String) bool {
+	if a.IsUnknown() || b.IsUnknown() {
+		return false
+	}
+	return strings.EqualFold(sharedCapacityAsEmpty(a.ValueString()), sharedCapacityAsEmpty(b.ValueString()))
+}
+
+// sharedCapacityAsEmpty returns the capacity id, empty for the shared capacity.
+func sharedCapacityAsEmpty(capacityId string) string {
+	if strings.EqualFold(capacityId, powerbiapi.EmptyId) {
+		return ""
+	}
+	return capacityId
+}
+
+// assignCapacity assigns the workspace to a capacity, or back to the shared capacity when capacityId is null
+// or the empty GUID, then waits for the asynchronous assignment to complete.
+func (r *WorkspaceResource) assignCapacity(ctx context.Context, workspaceId string, capacityId types.String) error {
+	var err error
+
+	if sharedCapacityAsEmpty(capacityId.ValueString()) == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Unassigning workspace %s from its capacity", workspaceId))
+		err = r.client.UnassignFromCapacity(ctx, workspaceId)
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Assigning workspace %s to capacity %s", workspaceId, capacityId.ValueString()))
+		err = r.client.AssignToCapacity(ctx, workspaceId, capacityId.ValueString())
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, capacityAssignmentTimeout)
+	defer cancel()
+
+	status, err := r.client.WaitForCapacityAssignment(ctx, workspaceId, capacityAssignmentPollInterval)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Capacity assignment of workspace %s completed at %s", workspaceId, status.EndTime))
+	return nil
+}
+
+// unassignPipelineStages unassigns the workspace from the deployment pipeline stages it is assigned to.
+func (r *WorkspaceResource) unassignPipelineStages(ctx context.Context, workspaceId string) error {
+	pipelines, err := r.client.GetPipelines(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pipeline := range pipelines.Value {
+		for _, stage := range pipeline.Stages {
+			if !strings.EqualFold(stage.WorkspaceId, workspaceId) {
+				continue
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("Unassigning workspace %s from stage %d of pipeline %s", workspaceId, stage.Order, pipeline.Id))
+			err = r.client.UnassignWorkspace(ctx, pipeline.Id, stage.Order)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// listWorkspaceContents returns a description of each report, dataset, dashboard and dataflow of the workspace.
+func listWorkspaceContents(ctx context.Context, client *powerbiapi.Client, workspaceId string) ([]string, error) {
+	var contents []string
+
+	reports, err := client.GetReportsInGroup(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range reports.Value {
+		contents = append(contents, fmt.Sprintf("report %q (%s)", report.Name, report.Id))
+	}
+
+	datasets, err := client.GetDatasetsInGroup(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	for _, dataset := range datasets.Value {
+		contents = append(contents, fmt.Sprintf("dataset %q (%s)", dataset.Name, dataset.Id))
+	}
+
+	dashboards, err := client.GetDashboardsInGroup(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	for _, dashboard := range dashboards.Value {
+		contents = append(contents, fmt.Sprintf("dashboard %q (%s)", dashboard.DisplayName, dashboard.Id))
+	}
+
+	dataflows, err := client.GetDataflows(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	for _, dataflow := range dataflows.Value {
+		contents = append(contents, fmt.Sprintf("dataflow %q (%s)", dataflow.Name, dataflow.ObjectId))
+	}
+
+	return contents, nil
+}
+
+// findWorkspaceByName returns the workspace with the given name, fully populated by GetGroup.
+// Workspace names are compared ignoring case, like the Power BI service does. It fails when no workspace
+// or several workspaces visible to the caller have this name.
+func findWorkspaceByName(ctx context.Context, client *powerbiapi.Client, name string) (*pbiModels.Group, error) {
+	ids, err := listWorkspaceIdsByName(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("no workspace named %q was found", name)
+	case 1:
+		return client.GetGroup(ctx, ids[0])
+	default:
+		return nil, fmt.Errorf("%d workspaces are named %q (%s), import one of them by Id", len(ids), name, strings.Join(ids, ", "))
+	}
+}
+
+// listWorkspaceIdsByName returns the ids of the workspaces named name, compared case-insensitively like the service does.
+func listWorkspaceIdsByName(ctx context.Context, client *powerbiapi.Client, name string) ([]string, error) {
+	filter := fmt.Sprintf("name eq '%s'", strings.ReplaceAll(name, "'", "''"))
+
+	var ids []string
+	pager := client.NewGroupsPager(&powerbiapi.GroupsPagerOptions{Filter: filter})
+	for pager.More() {
+		groups, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups.Value {
+			if strings.EqualFold(group.Name, name) {
+				ids = append(ids, group.Id)
+			}
+		}
+	}
+
+	return ids, nil
+}
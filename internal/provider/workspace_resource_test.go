@@ -0,0 +1,842 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspaceResource creates, imports, renames and destroys a workspace, and repairs a rename made in the portal.
+func TestAccWorkspaceResource(t *testing.T) {
+	server := testAccServer(t)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWorkspaceResourceConfig(server, "tf-acc-workspace"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "name", "tf-acc-workspace"),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "is_read_only", "false"),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "is_on_dedicated_capacity", "false"),
+					resource.TestCheckResourceAttrSet("powerbi_workspace.test", "id"),
+					testAccCaptureId("powerbi_workspace.test", &id),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerbi_workspace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState testing by name, ignoring case
+			{
+				ResourceName:      "powerbi_workspace.test",
+				ImportState:       true,
+				ImportStateId:     "name:TF-ACC-Workspace",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccWorkspaceResourceConfig(server, "tf-acc-workspace-renamed"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "name", "tf-acc-workspace-renamed"),
+					resource.TestCheckResourceAttrPtr("powerbi_workspace.test", "id", &id),
+				),
+			},
+			// Drift testing: the workspace is renamed outside of Terraform, the next apply renames it back.
+			{
+				PreConfig: func() {
+					group, _ := server.Group(id)
+					group.Name = "renamed-in-the-portal"
+					server.PutGroup(group)
+				},
+				Config: testAccWorkspaceResourceConfig(server, "tf-acc-workspace-renamed"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "name", "tf-acc-workspace-renamed"),
+					testAccCheckWorkspaceName(server, &id, "tf-acc-workspace-renamed"),
+				),
+			},
+			// Drift testing: the workspace is deleted outside of Terraform, the next apply creates it again.
+			{
+				PreConfig: func() {
+					server.RemoveGroup(id)
+				},
+				Config: testAccWorkspaceResourceConfig(server, "tf-acc-workspace-renamed"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "name", "tf-acc-workspace-renamed"),
+					testAccCaptureId("powerbi_workspace.test", &id),
+					testAccCheckWorkspaceName(server, &id, "tf-acc-workspace-renamed"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// TestAccWorkspaceResource_importByName checks that importing by name fails when the name is missing or ambiguous.
+func TestAccWorkspaceResource_importByName(t *testing.T) {
+	server := testAccServer(t)
+	first := server.PutGroup(fake.Group{Name: "tf-acc-o'brien"})
+	second := server.PutGroup(fake.Group{Name: "TF-ACC-O'Brien"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccWorkspaceResourceConfig(server, "tf-acc-o'brien"),
+				ResourceName:  "powerbi_workspace.test",
+				ImportState:   true,
+				ImportStateId: "name:tf-acc-missing",
+				ExpectError:   regexp.MustCompile(`no workspace named "tf-acc-missing" was found`),
+			},
+			{
+				Config:        testAccWorkspaceResourceConfig(server, "tf-acc-o'brien"),
+				ResourceName:  "powerbi_workspace.test",
+				ImportState:   true,
+				ImportStateId: "name:tf-acc-o'brien",
+				ExpectError:   regexp.MustCompile(`2 workspaces are named "tf-acc-o'brien"`),
+			},
+			{
+				PreConfig:     func() { server.RemoveGroup(second.Id) },
+				Config:        testAccWorkspaceResourceConfig(server, "tf-acc-o'brien"),
+				ResourceName:  "powerbi_workspace.test",
+				ImportState:   true,
+				ImportStateId: "name:tf-acc-o'brien",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].ID != first.Id {
+						return fmt.Errorf("expected workspace %s to be imported, got %v", first.Id, states)
+					}
+					if name := states[0].Attributes["name"]; name != "tf-acc-o'brien" {
+						return fmt.Errorf("unexpected name %q", name)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_capacity creates a workspace on a capacity, moves it to another capacity,
+// then back to the shared capacity, waiting each time for the asynchronous assignment to complete.
+func TestAccWorkspaceResource_capacity(t *testing.T) {
+	server := testAccServer(t)
+	first := server.PutCapacity(fake.Capacity{DisplayName: "F2"})
+	second := server.PutCapacity(fake.Capacity{DisplayName: "P1"})
+	server.DelayCapacityAssignments(2)
+
+	interval := capacityAssignmentPollInterval
+	capacityAssignmentPollInterval = time.Millisecond
+	t.Cleanup(func() { capacityAssignmentPollInterval = interval })
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkspaceResourceCapacityConfig(server, "tf-acc-capacity", first.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", first.Id),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "is_on_dedicated_capacity", "true"),
+					testAccCaptureId("powerbi_workspace.test", &id),
+				),
+			},
+			{
+				ResourceName:      "powerbi_workspace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccWorkspaceResourceCapacityConfig(server, "tf-acc-capacity", second.Id),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", second.Id),
+					resource.TestCheckResourceAttrPtr("powerbi_workspace.test", "id", &id),
+				),
+			},
+			// The workspace stays on its capacity when capacity_id is unset.
+			{
+				Config: testAccWorkspaceResourceConfig(server, "tf-acc-capacity"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", second.Id),
+			},
+			// The capacity id is compared ignoring case, the workspace is not assigned again.
+			{
+				PreConfig: func() { server.DelayCapacityAssignments(0) },
+				Config:    testAccWorkspaceResourceCapacityConfig(server, "tf-acc-capacity", strings.ToUpper(second.Id)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", strings.ToUpper(second.Id)),
+					testAccCheckWorkspaceCapacity(server, &id, second.Id),
+				),
+			},
+			{
+				Config: testAccWorkspaceResourceCapacityConfig(server, "tf-acc-capacity", powerbiapi.EmptyId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", powerbiapi.EmptyId),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "is_on_dedicated_capacity", "false"),
+					resource.TestCheckResourceAttrPtr("powerbi_workspace.test", "id", &id),
+					testAccCheckWorkspaceCapacity(server, &id, ""),
+				),
+			},
+			{
+				Config:      testAccWorkspaceResourceCapacityConfig(server, "tf-acc-capacity", "0f084df7-c13d-451b-af5f-ed0c466403b2"),
+				ExpectError: regexp.MustCompile(`capacity assignment of group .* failed`),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_defaultDatasetStorageFormat sets the default dataset storage format of a workspace
+// on a capacity, changes it alongside a rename, and rejects it on the shared capacity or with an unknown value.
+func TestAccWorkspaceResource_defaultDatasetStorageFormat(t *testing.T) {
+	server := testAccServer(t)
+	capacity := server.PutCapacity(fake.Capacity{DisplayName: "F2"})
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWorkspaceResourceStorageFormatConfig(server, "tf-acc-storage", "", "Large"),
+				ExpectError: regexp.MustCompile(`Attribute "capacity_id" must be specified when\s+"default_dataset_storage_format"`),
+			},
+			{
+				// The empty GUID is the shared capacity.
+				Config:      testAccWorkspaceResourceStorageFormatConfig(server, "tf-acc-storage", powerbiapi.EmptyId, "Large"),
+				ExpectError: regexp.MustCompile(`Dedicated capacity required`),
+			},
+			{
+				Config:      testAccWorkspaceResourceStorageFormatConfig(server, "tf-acc-storage", capacity.Id, "Medium"),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: testAccWorkspaceResourceStorageFormatConfig(server, "tf-acc-storage", capacity.Id, "Large"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "default_dataset_storage_format", "Large"),
+					testAccCaptureId("powerbi_workspace.test", &id),
+					testAccCheckWorkspaceStorageFormat(server, &id, "Large"),
+				),
+			},
+			{
+				Config: testAccWorkspaceResourceStorageFormatConfig(server, "tf-acc-storage-renamed", capacity.Id, "Small"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "name", "tf-acc-storage-renamed"),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "default_dataset_storage_format", "Small"),
+					testAccCheckWorkspaceName(server, &id, "tf-acc-storage-renamed"),
+					testAccCheckWorkspaceStorageFormat(server, &id, "Small"),
+				),
+			},
+			{
+				Config: testAccWorkspaceResourceCapacityConfig(server, "tf-acc-storage-renamed", powerbiapi.EmptyId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", powerbiapi.EmptyId),
+					resource.TestCheckNoResourceAttr("powerbi_workspace.test", "default_dataset_storage_format"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_logAnalytics assigns a Log Analytics workspace to a workspace on a capacity, replaces it,
+// repairs an unassignment made in the portal, and unassigns it.
+func TestAccWorkspaceResource_logAnalytics(t *testing.T) {
+	server := testAccServer(t)
+	capacity := server.PutCapacity(fake.Capacity{DisplayName: "P1"})
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWorkspaceResourceLogAnalyticsConfig(server, "", "law-powerbi"),
+				ExpectError: regexp.MustCompile(`Attribute "capacity_id" must be specified when "log_analytics" is\s+specified`),
+			},
+			{
+				Config:      testAccWorkspaceResourceLogAnalyticsConfig(server, powerbiapi.EmptyId, "law-powerbi"),
+				ExpectError: regexp.MustCompile(`Dedicated capacity required`),
+			},
+			{
+				Config: testAccWorkspaceResourceLogAnalyticsConfig(server, capacity.Id, "law-powerbi"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "log_analytics.subscription_id", "2a1b6d4e-6c4a-4b8e-9f0d-3c2e1b0a9f8e"),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "log_analytics.resource_group", "rg-monitoring"),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "log_analytics.resource_name", "law-powerbi"),
+					testAccCaptureId("powerbi_workspace.test", &id),
+					testAccCheckWorkspaceLogAnalytics(server, &id, "law-powerbi"),
+				),
+			},
+			{
+				ResourceName:      "powerbi_workspace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccWorkspaceResourceLogAnalyticsConfig(server, capacity.Id, "law-powerbi-2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "log_analytics.resource_name", "law-powerbi-2"),
+					testAccCheckWorkspaceLogAnalytics(server, &id, "law-powerbi-2"),
+				),
+			},
+			// Drift testing: the Log Analytics workspace is unassigned outside of Terraform, the next apply assigns it again.
+			{
+				PreConfig: func() {
+					group, _ := server.Group(id)
+					group.LogAnalyticsWorkspace = nil
+					server.PutGroup(group)
+				},
+				Config: testAccWorkspaceResourceLogAnalyticsConfig(server, capacity.Id, "law-powerbi-2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckWorkspaceLogAnalytics(server, &id, "law-powerbi-2"),
+			},
+			{
+				Config: testAccWorkspaceResourceCapacityConfig(server, "tf-acc-log-analytics", capacity.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("powerbi_workspace.test", "log_analytics.resource_name"),
+					testAccCheckWorkspaceLogAnalytics(server, &id, ""),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_forceDestroy refuses to destroy a workspace with contents, then destroys it
+// with its contents once force_destroy is set, unassigning it from its deployment pipeline stage first.
+func TestAccWorkspaceResource_forceDestroy(t *testing.T) {
+	server := testAccServer(t)
+
+	var id string
+	var pipeline fake.Pipeline
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckWorkspaceDestroy(server),
+			func(*terraform.State) error {
+				p, _ := server.Pipeline(pipeline.Id)
+				if p.Stages[0].WorkspaceId != "" {
+					return fmt.Errorf("stage 0 of pipeline %s is still assigned to workspace %s", p.Id, p.Stages[0].WorkspaceId)
+				}
+				return nil
+			},
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkspaceResourceConfig(server, "tf-acc-force-destroy"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "force_destroy", "false"),
+					testAccCaptureId("powerbi_workspace.test", &id),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.PutReport(id, fake.Report{Name: "Sales"})
+					server.PutDataset(id, fake.Dataset{Name: "Sales"})
+					pipeline = server.PutPipeline(fake.Pipeline{
+						DisplayName: "tf-acc-force-destroy",
+						Stages:      []fake.PipelineStage{{Order: 0, WorkspaceId: id}},
+					})
+				},
+				Config:      testAccWorkspaceResourceConfig(server, "tf-acc-force-destroy"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`(?s)report "Sales".*dataset "Sales".*force_destroy`),
+			},
+			{
+				Config: testAccWorkspaceResourceForceDestroyConfig(server, "tf-acc-force-destroy"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "force_destroy", "true"),
+					resource.TestCheckResourceAttrPtr("powerbi_workspace.test", "id", &id),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_adoptExisting fails to create a workspace named like an existing one,
+// then takes the existing workspace under management once adopt_existing is set, renaming it as configured
+// and keeping it on its capacity.
+func TestAccWorkspaceResource_adoptExisting(t *testing.T) {
+	server := testAccServer(t)
+	capacity := server.PutCapacity(fake.Capacity{DisplayName: "F2"})
+	existing := server.PutGroup(fake.Group{Name: "tf-acc-adopt", CapacityId: capacity.Id, IsOnDedicatedCapacity: true})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWorkspaceResourceConfig(server, "tf-acc-adopt"),
+				ExpectError: regexp.MustCompile(`PowerBIEntityAlreadyExists`),
+			},
+			{
+				Config: testAccWorkspaceResourceAdoptExistingConfig(server, "TF-ACC-ADOPT"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "id", existing.Id),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "name", "TF-ACC-ADOPT"),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "adopt_existing", "true"),
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", capacity.Id),
+					testAccCheckWorkspaceName(server, &existing.Id, "TF-ACC-ADOPT"),
+					testAccCheckWorkspaceCapacity(server, &existing.Id, capacity.Id),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_adoptExistingFailure checks that an adopted workspace is left out of the state when its
+// configuration fails, so the next apply adopts it again instead of replacing it.
+func TestAccWorkspaceResource_adoptExistingFailure(t *testing.T) {
+	server := testAccServer(t)
+	existing := server.PutGroup(fake.Group{Name: "tf-acc-adopt-failure"})
+	config := testAccProviderConfig(server) + `
+resource "powerbi_workspace" "test" {
+  name           = "tf-acc-adopt-failure"
+  description    = "Sales reports"
+  adopt_existing = true
+  force_destroy  = true
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { server.ForbidFabric(true) },
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Cannot set the description of workspace`),
+			},
+			{
+				PreConfig: func() { server.ForbidFabric(false) },
+				Config:    config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "id", existing.Id),
+					testAccCheckWorkspaceDescription(server, &existing.Id, "Sales reports"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_description sets and changes the description of a workspace through the Fabric API,
+// and checks that a workspace is still managed, without description, when the Fabric API is not available.
+func TestAccWorkspaceResource_description(t *testing.T) {
+	server := testAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				// The description is rejected at plan time without the Fabric API, before the workspace is created.
+				Config: testAccProviderConfigWithoutFabric(server) + `
+resource "powerbi_workspace" "test" {
+  name        = "tf-acc-description"
+  description = "Sales reports"
+}
+`,
+				ExpectError: regexp.MustCompile(`Fabric API not available`),
+			},
+			{
+				PreConfig: func() {
+					if groups := server.Groups(); len(groups) != 0 {
+						t.Errorf("expected no workspace, got %d", len(groups))
+					}
+				},
+				Config: testAccWorkspaceResourceDescriptionConfig(server, "tf-acc-description", "Sales reports"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "description", "Sales reports"),
+					testAccCaptureId("powerbi_workspace.test", &id),
+					testAccCheckWorkspaceDescription(server, &id, "Sales reports"),
+				),
+			},
+			{
+				ResourceName:      "powerbi_workspace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccWorkspaceResourceDescriptionConfig(server, "tf-acc-description-renamed", "Sales and finance reports"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "description", "Sales and finance reports"),
+					testAccCheckWorkspaceName(server, &id, "tf-acc-description-renamed"),
+					testAccCheckWorkspaceDescription(server, &id, "Sales and finance reports"),
+				),
+			},
+			{
+				// An unset description is left unchanged.
+				Config: testAccWorkspaceResourceConfig(server, "tf-acc-description-renamed"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: testAccCheckWorkspaceDescription(server, &id, "Sales and finance reports"),
+			},
+			{
+				// Without the Fabric API, the description kept in the state does not drift.
+				Config: testAccProviderConfigWithoutFabric(server) + `
+resource "powerbi_workspace" "test" {
+  name = "tf-acc-description-renamed"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_descriptionForbidden checks that the Fabric API is not called for a workspace without
+// description, and that a workspace with a description is still refreshed and updated when the Fabric API answers 403.
+func TestAccWorkspaceResource_descriptionForbidden(t *testing.T) {
+	server := testAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { server.ForbidFabric(true) },
+				Config:    testAccWorkspaceResourceConfig(server, "tf-acc-forbidden"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("powerbi_workspace.test", "description"),
+					testAccCaptureId("powerbi_workspace.test", &id),
+					testAccCheckNoFabricRequests(server),
+				),
+			},
+			{
+				PreConfig: func() { server.ForbidFabric(false) },
+				Config:    testAccWorkspaceResourceDescriptionConfig(server, "tf-acc-forbidden", "Sales reports"),
+				Check:     testAccCheckWorkspaceDescription(server, &id, "Sales reports"),
+			},
+			{
+				// The description kept in the state does not drift when the Fabric API denies access.
+				PreConfig: func() { server.ForbidFabric(true) },
+				Config:    testAccWorkspaceResourceDescriptionConfig(server, "tf-acc-forbidden", "Sales reports"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: testAccWorkspaceResourceDescriptionConfig(server, "tf-acc-forbidden-renamed", "Sales reports"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "description", "Sales reports"),
+					testAccCheckWorkspaceName(server, &id, "tf-acc-forbidden-renamed"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceResource_planChecks rejects invalid names and a rename onto another workspace at plan time,
+// and keeps the computed attributes known when a workspace is renamed.
+func TestAccWorkspaceResource_planChecks(t *testing.T) {
+	server := testAccServer(t)
+	taken := server.PutGroup(fake.Group{Name: "tf-acc-taken"})
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWorkspaceResourceConfig(server, " tf-acc-plan"),
+				ExpectError: regexp.MustCompile(`must not start or end with a space`),
+			},
+			{
+				Config:      testAccWorkspaceResourceConfig(server, strings.Repeat("x", 257)),
+				ExpectError: regexp.MustCompile(`string length must be between 1 and 256`),
+			},
+			{
+				Config: testAccWorkspaceResourceConfig(server, "tf-acc-plan"),
+				Check:  testAccCaptureId("powerbi_workspace.test", &id),
+			},
+			{
+				Config:      testAccWorkspaceResourceConfig(server, "TF-ACC-TAKEN"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Workspace name already in use.*` + taken.Id),
+			},
+			{
+				// A rename changing the case only is not a conflict with the workspace itself.
+				Config: testAccWorkspaceResourceConfig(server, "TF-ACC-PLAN"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace.test", plancheck.ResourceActionUpdate),
+						testAccExpectKnownValues("powerbi_workspace.test", "id", "is_read_only", "is_on_dedicated_capacity", "description"),
+					},
+				},
+				Check: testAccCheckWorkspaceName(server, &id, "TF-ACC-PLAN"),
+			},
+		},
+	})
+}
+
+func testAccWorkspaceResourceConfig(server *fake.Server, name string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace" "test" {
+  name = %q
+}
+`, name)
+}
+
+func testAccWorkspaceResourceForceDestroyConfig(server *fake.Server, name string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace" "test" {
+  name          = %q
+  force_destroy = true
+}
+`, name)
+}
+
+func testAccWorkspaceResourceAdoptExistingConfig(server *fake.Server, name string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace" "test" {
+  name           = %q
+  adopt_existing = true
+}
+`, name)
+}
+
+func testAccWorkspaceResourceDescriptionConfig(server *fake.Server, name string, description string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace" "test" {
+  name        = %q
+  description = %q
+}
+`, name, description)
+}
+
+// testAccCheckNoFabricRequests checks that the fake server did not receive any request for the Fabric API.
+func testAccCheckNoFabricRequests(server *fake.Server) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		for _, request := range server.Requests() {
+			if strings.HasPrefix(request.Path, "/v1/") {
+				return fmt.Errorf("unexpected Fabric API request %s %s", request.Method, request.Path)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckWorkspaceDescription checks the description of the workspace on the service side.
+func testAccCheckWorkspaceDescription(server *fake.Server, id *string, description string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		group, ok := server.Group(*id)
+		if !ok {
+			return fmt.Errorf("workspace %s does not exist", *id)
+		}
+		if group.Description != description {
+			return fmt.Errorf("workspace %s is described as %q, expected %q", *id, group.Description, description)
+		}
+		return nil
+	}
+}
+
+// testAccCheckWorkspaceName checks the name of the workspace on the service side.
+func testAccCheckWorkspaceName(server *fake.Server, id *string, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		group, ok := server.Group(*id)
+		if !ok {
+			return fmt.Errorf("workspace %s does not exist", *id)
+		}
+		if group.Name != name {
+			return fmt.Errorf("workspace %s is named %q, expected %q", *id, group.Name, name)
+		}
+		return nil
+	}
+}
+
+// testAccCheckWorkspaceDestroy checks that every workspace in the state was deleted from the service.
+func testAccCheckWorkspaceDestroy(server *fake.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "powerbi_workspace" {
+				continue
+			}
+			if _, ok := server.Group(rs.Primary.ID); ok {
+				return fmt.Errorf("workspace %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccWorkspaceResourceCapacityConfig(server *fake.Server, name string, capacityId string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace" "test" {
+  name        = %q
+  capacity_id = %q
+}
+`, name, capacityId)
+}
+
+// testAccCheckWorkspaceCapacity checks the capacity of the workspace on the service side, empty for the shared capacity.
+func testAccCheckWorkspaceCapacity(server *fake.Server, id *string, capacityId string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		group, ok := server.Group(*id)
+		if !ok {
+			return fmt.Errorf("workspace %s does not exist", *id)
+		}
+		if group.CapacityId != capacityId {
+			return fmt.Errorf("workspace %s is on capacity %q, expected %q", *id, group.CapacityId, capacityId)
+		}
+		return nil
+	}
+}
+
+func testAccWorkspaceResourceStorageFormatConfig(server *fake.Server, name string, capacityId string, format string) string {
+	capacity := "null"
+	if capacityId != "" {
+		capacity = fmt.Sprintf("%q", capacityId)
+	}
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace" "test" {
+  name                           = %q
+  capacity_id                    = %s
+  default_dataset_storage_format = %q
+}
+`, name, capacity, format)
+}
+
+// testAccCheckWorkspaceStorageFormat checks the default dataset storage format of the workspace on the service side.
+func testAccCheckWorkspaceStorageFormat(server *fake.Server, id *string, format string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		group, ok := server.Group(*id)
+		if !ok {
+			return fmt.Errorf("workspace %s does not exist", *id)
+		}
+		if group.DefaultDatasetStorageFormat != format {
+			return fmt.Errorf("workspace %s has the %q default dataset storage format, expected %q", *id, group.DefaultDatasetStorageFormat, format)
+		}
+		return nil
+	}
+}
+
+func testAccWorkspaceResourceLogAnalyticsConfig(server *fake.Server, capacityId string, resourceName string) string {
+	capacity := "null"
+	if capacityId != "" {
+		capacity = fmt.Sprintf("%q", capacityId)
+	}
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace" "test" {
+  name        = "tf-acc-log-analytics"
+  capacity_id = %s
+
+  log_analytics = {
+    subscription_id = "2a1b6d4e-6c4a-4b8e-9f0d-3c2e1b0a9f8e"
+    resource_group  = "rg-monitoring"
+    resource_name   = %q
+  }
+}
+`, capacity, resourceName)
+}
+
+// testAccCheckWorkspaceLogAnalytics checks the name of the Log Analytics workspace assigned to the workspace
+// on the service side, empty when none is.
+func testAccCheckWorkspaceLogAnalytics(server *fake.Server, id *string, resourceName string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		group, ok := server.Group(*id)
+		if !ok {
+			return fmt.Errorf("workspace %s does not exist", *id)
+		}
+		var assigned string
+		if group.LogAnalyticsWorkspace != nil {
+			assigned = group.LogAnalyticsWorkspace.ResourceName
+		}
+		if assigned != resourceName {
+			return fmt.Errorf("workspace %s is assigned the Log Analytics workspace %q, expected %q", *id, assigned, resourceName)
+		}
+		return nil
+	}
+}
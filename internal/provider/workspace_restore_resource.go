@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+var _ resource.Resource = &WorkspaceRestoreResource{}              // Ensure that WorkspaceRestoreResource implements the Resource interface.
+var _ resource.ResourceWithConfigure = &WorkspaceRestoreResource{} // Ensure that WorkspaceRestoreResource implements the ResourceWithConfigure interface.
+
+// NewWorkspaceRestoreResource is a function that creates a new instance of the WorkspaceRestoreResource.
+func NewWorkspaceRestoreResource() resource.Resource {
+	return &WorkspaceRestoreResource{}
+}
+
+// WorkspaceRestoreResource is a struct that represents the restoration of a deleted Power BI workspace
+// through the admin API.
+type WorkspaceRestoreResource struct {
+	client *powerbiapi.Client
+}
+
+// Configure configures the WorkspaceRestoreResource.
+func (r *WorkspaceRestoreResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerbiapi.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *powerbiapi.Client, got: %T. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create restores the deleted workspace, with the configured admin and name.
+func (r *WorkspaceRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.WorkspaceRestore
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaceId := plan.WorkspaceId.ValueString()
+
+	workspace, err := findDeletedWorkspace(ctx, r.client, workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve deleted workspace with Id %s", workspaceId), err.Error())
+		return
+	}
+	if workspace == nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Cannot restore workspace with Id %s", workspaceId),
+			"No deleted workspace has this Id. It may not exist, have been restored already, or have been removed at the end of its retention period.",
+		)
+		return
+	}
+
+	// The workspace keeps its name unless another one is configured.
+	if plan.Name.IsUnknown() || plan.Name.IsNull() {
+		plan.Name = types.StringValue(workspace.Name)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Restoring workspace %s as %s with admin %s", workspaceId, plan.Name.ValueString(), plan.AdminEmailAddress.ValueString()))
+	err = r.client.RestoreDeletedGroupAsAdmin(ctx, workspaceId, &pbiModels.GroupRestoreRequest{
+		EmailAddress: plan.AdminEmailAddress.ValueString(),
+		Name:         plan.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot restore workspace with Id %s", workspaceId), err.Error())
+		return
+	}
+
+	plan.Id = plan.WorkspaceId
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read checks the workspace is still restored.
+// The resource is removed from the state when the workspace is deleted again, so the next apply restores it.
+func (r *WorkspaceRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.WorkspaceRestore
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Checking whether workspace %s is deleted", state.Id.ValueString()))
+	workspace, err := findDeletedWorkspace(ctx, r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Cannot retrieve deleted workspace with Id %s", state.Id.ValueString()), err.Error())
+		return
+	}
+
+	if workspace != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Workspace with Id %s is deleted again, removing its restoration from the state", state.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called: every attribute requires the replacement of the resource.
+func (r *WorkspaceRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.WorkspaceRestore
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete only removes the restoration from the state, the restored workspace is kept.
+func (r *WorkspaceRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.WorkspaceRestore
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Removing the restoration of workspace %s from the state, the workspace is kept", state.Id.ValueString()))
+}
+
+// Metadata sets the metadata for the WorkspaceRestoreResource.
+func (r *WorkspaceRestoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspace_restore"
+}
+
+// Schema sets the schema for the WorkspaceRestoreResource.
+func (r *WorkspaceRestoreResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Restores a deleted Power BI workspace, listed by the `powerbi_deleted_workspaces` data source, with a new admin and optionally a new name. It uses the admin API: the provider must authenticate as a Fabric administrator. " +
+			"The workspace is restored again if it is deleted while the resource exists, destroying the resource keeps the workspace",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the workspace",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the deleted workspace",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the restored workspace. The workspace keeps its name when it is not set",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin_email_address": schema.StringAttribute{
+				MarkdownDescription: "The email address of the user who becomes the admin of the restored workspace",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspaceRestoreResource refuses to restore an active workspace, restores a deleted one keeping its name,
+// restores it again when it is deleted outside of Terraform, and keeps it when the resource is destroyed.
+func TestAccWorkspaceRestoreResource(t *testing.T) {
+	server := testAccServer(t)
+	active := server.PutGroup(fake.Group{Name: "tf-acc-active"})
+	deleted := server.PutDeletedGroup(fake.Group{Name: "tf-acc-deleted"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWorkspaceRestored(server, deleted.Id, "tf-acc-deleted"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWorkspaceRestoreResourceConfig(server, active.Id, ""),
+				ExpectError: regexp.MustCompile(`No deleted workspace has this Id`),
+			},
+			{
+				Config: testAccWorkspaceRestoreResourceConfig(server, deleted.Id, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace_restore.test", "id", deleted.Id),
+					resource.TestCheckResourceAttr("powerbi_workspace_restore.test", "name", "tf-acc-deleted"),
+					testAccCheckWorkspaceRestored(server, deleted.Id, "tf-acc-deleted"),
+				),
+			},
+			// Drift testing: the workspace is deleted again outside of Terraform, the next apply restores it.
+			{
+				PreConfig: func() {
+					g, _ := server.Group(deleted.Id)
+					server.RemoveGroup(deleted.Id)
+					server.PutDeletedGroup(g)
+				},
+				Config: testAccWorkspaceRestoreResourceConfig(server, deleted.Id, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("powerbi_workspace_restore.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckWorkspaceRestored(server, deleted.Id, "tf-acc-deleted"),
+			},
+		},
+	})
+}
+
+// TestAccWorkspaceRestoreResource_name restores a deleted workspace under a new name, its name being taken.
+func TestAccWorkspaceRestoreResource_name(t *testing.T) {
+	server := testAccServer(t)
+	server.PutGroup(fake.Group{Name: "tf-acc-restore"})
+	deleted := server.PutDeletedGroup(fake.Group{Name: "tf-acc-restore"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWorkspaceRestoreResourceConfig(server, deleted.Id, ""),
+				ExpectError: regexp.MustCompile(`PowerBIEntityAlreadyExists`),
+			},
+			{
+				Config: testAccWorkspaceRestoreResourceConfig(server, deleted.Id, "tf-acc-restore (restored)"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace_restore.test", "name", "tf-acc-restore (restored)"),
+					testAccCheckWorkspaceRestored(server, deleted.Id, "tf-acc-restore (restored)"),
+				),
+			},
+		},
+	})
+}
+
+// testAccWorkspaceRestoreResourceConfig returns the configuration restoring a deleted workspace, under a new name when name is set.
+func testAccWorkspaceRestoreResourceConfig(server *fake.Server, workspaceId string, name string) string {
+	nameAttribute := ""
+	if name != "" {
+		nameAttribute = fmt.Sprintf("name = %q", name)
+	}
+
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "powerbi_workspace_restore" "test" {
+  workspace_id        = %q
+  admin_email_address = "john.doe@contoso.com"
+  %s
+}
+`, workspaceId, nameAttribute)
+}
+
+// testAccCheckWorkspaceRestored checks the workspace is active under the given name, with the configured admin.
+func testAccCheckWorkspaceRestored(server *fake.Server, workspaceId string, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		g, ok := server.Group(workspaceId)
+		if !ok {
+			return fmt.Errorf("workspace %s is not restored", workspaceId)
+		}
+		if g.Name != name {
+			return fmt.Errorf("workspace %s is named %q, expected %q", workspaceId, g.Name, name)
+		}
+		for _, user := range server.GroupUsers(workspaceId) {
+			if user.EmailAddress == "john.doe@contoso.com" && user.GroupUserAccessRight == "Admin" {
+				return nil
+			}
+		}
+		return fmt.Errorf("john.doe@contoso.com is not an admin of workspace %s", workspaceId)
+	}
+}
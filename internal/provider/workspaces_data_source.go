@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider/models"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	pbiModels "github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+var (
+	_ datasource.DataSource              = &WorkspacesDataSource{} // Ensure that WorkspacesDataSource implements the DataSource interface.
+	_ datasource.DataSourceWithConfigure = &WorkspacesDataSource{} // Ensure that WorkspacesDataSource implements the DataSourceWithConfigure interface.
+)
+
+// NewWorkspacesDataSource is a function that creates a new instance of the WorkspacesDataSource.
+func NewWorkspacesDataSource() datasource.DataSource {
+	return &WorkspacesDataSource{}
+}
+
+// WorkspacesDataSource is a struct that represents the Power BI workspaces data source.
+type WorkspacesDataSource struct {
+	client *powerbiapi.Client
+}
+
+// Metadata is a method that sets the metadata for the WorkspacesDataSource.
+func (d *WorkspacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspaces"
+}
+
+// Schema is a method that sets the schema for the WorkspacesDataSource.
+func (d *WorkspacesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Power BI workspaces data source, listing the workspaces the user has access to that match all the configured filters",
+
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list the workspaces whose name starts with this prefix, ignoring the case",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name_contains": schema.StringAttribute{
+				MarkdownDescription: "Only list the workspaces whose name contains this value, ignoring the case",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"capacity_id": schema.StringAttribute{
+				MarkdownDescription: "Only list the workspaces assigned to this capacity",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"is_read_only": schema.BoolAttribute{
+				MarkdownDescription: "Only list the workspaces that are read-only, or that are not when false",
+				Optional:            true,
+			},
+			"is_on_dedicated_capacity": schema.BoolAttribute{
+				MarkdownDescription: "Only list the workspaces on a dedicated capacity, or on the shared capacity when false",
+				Optional:            true,
+			},
+			"workspaces": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching workspaces",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the workspace",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the workspace",
+							Computed:            true,
+						},
+						"capacity_id": schema.StringAttribute{
+							MarkdownDescription: "The id of the capacity of the workspace",
+							Computed:            true,
+						},
+						"default_dataset_storage_format": schema.StringAttribute{
+							MarkdownDescription: "The default dataset storage format of the workspace",
+							Computed:            true,
+						},
+						"is_read_only": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether the workspace is read-only",
+							Computed:            true,
+						},
+						"is_on_dedicated_capacity": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether the workspace is on a dedicated capacity",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure is a method that configures the WorkspacesDataSource.
+func (d *WorkspacesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerbiapi.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *powerbiapi.Client, got: %T. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read is a method that lists the matching workspaces from the Power BI service.
+func (d *WorkspacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.WorkspacesData
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The name filters are applied by the service, the other ones once the workspaces are listed.
+	filter := workspacesFilter(data)
+	tflog.Debug(ctx, fmt.Sprintf("Listing the workspaces with filter %q", filter))
+
+	data.Workspaces = []models.WorkspaceSummary{}
+	pager := d.client.NewGroupsPager(&powerbiapi.GroupsPagerOptions{Filter: filter})
+	for pager.More() {
+		groups, err := pager.NextPage(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot list the workspaces", err.Error())
+			return
+		}
+
+		for _, group := range groups.Value {
+			if !workspaceMatches(data, group) {
+				continue
+			}
+			data.Workspaces = append(data.Workspaces, models.WorkspaceSummary{
+				Id:                          types.StringValue(group.Id),
+				Name:                        types.StringValue(group.Name),
+				CapacityId:                  optionalString(group.CapacityId),
+				DefaultDatasetStorageFormat: optionalString(string(group.DefaultDatasetStorageFormat)),
+				IsReadOnly:                  types.BoolValue(group.IsReadOnly),
+				IsOnDedicatedCapacity:       types.BoolValue(group.IsOnDedicatedCapacity),
+			})
+		}
+	}
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// workspacesFilter returns the OData filter on the workspace name matching the name filters of the data source.
+func workspacesFilter(data models.WorkspacesData) string {
+	var clauses []string
+
+	if !data.NamePrefix.IsNull() {
+		clauses = append(clauses, fmt.Sprintf("startswith(name,'%s')", strings.ReplaceAll(data.NamePrefix.ValueString(), "'", "''")))
+	}
+	if !data.NameContains.IsNull() {
+		clauses = append(clauses, fmt.Sprintf("contains(name,'%s')", strings.ReplaceAll(data.NameContains.ValueString(), "'", "''")))
+	}
+
+	return strings.Join(clauses, " and ")
+}
+
+// workspaceMatches tells whether the workspace matches the capacity and flag filters of the data source.
+func workspaceMatches(data models.WorkspacesData, group pbiModels.Group) bool {
+	if !data.CapacityId.IsNull() && !strings.EqualFold(data.CapacityId.ValueString(), group.CapacityId) {
+		return false
+	}
+	if !data.IsReadOnly.IsNull() && data.IsReadOnly.ValueBool() != group.IsReadOnly {
+		return false
+	}
+	if !data.IsOnDedicatedCapacity.IsNull() && data.IsOnDedicatedCapacity.ValueBool() != group.IsOnDedicatedCapacity {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestAccWorkspacesDataSource lists the workspaces matching the name, capacity and flag filters.
+func TestAccWorkspacesDataSource(t *testing.T) {
+	server := testAccServer(t)
+	capacityId := "FEFC7A26-4758-41CD-9069-5E73B7E9DB0E"
+	sales := server.PutGroup(fake.Group{Name: "tf-acc-sales", IsOnDedicatedCapacity: true, CapacityId: capacityId})
+	finance := server.PutGroup(fake.Group{Name: "TF-ACC-finance"})
+	server.PutGroup(fake.Group{Name: "tf-acc-archive", IsReadOnly: true})
+	server.PutGroup(fake.Group{Name: "other-sales"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "powerbi_workspaces" "prefix" {
+  name_prefix  = "tf-acc-"
+  is_read_only = false
+}
+
+data "powerbi_workspaces" "contains" {
+  name_contains = "SALES"
+}
+
+data "powerbi_workspaces" "capacity" {
+  capacity_id = "` + capacityId + `"
+}
+
+data "powerbi_workspaces" "none" {
+  name_prefix              = "tf-acc-"
+  is_on_dedicated_capacity = true
+  is_read_only             = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.prefix", "workspaces.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.prefix", "workspaces.0.id", sales.Id),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.prefix", "workspaces.0.capacity_id", capacityId),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.prefix", "workspaces.0.is_on_dedicated_capacity", "true"),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.prefix", "workspaces.1.id", finance.Id),
+					resource.TestCheckNoResourceAttr("data.powerbi_workspaces.prefix", "workspaces.1.capacity_id"),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.contains", "workspaces.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.capacity", "workspaces.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.capacity", "workspaces.0.name", "tf-acc-sales"),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.none", "workspaces.#", "0"),
+				),
+			},
+		},
+	})
+}
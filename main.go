@@ -8,9 +8,9 @@ import (
 	"flag"
 	"log"
 
-	"terraform-provider-powerbi/internal/provider"
-
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/internal/provider"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -0,0 +1,124 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// GetGroupsAsAdmin retrieves a page of the workspaces of the organization, including the deleted ones.
+// The caller must be a Fabric administrator, top is mandatory and at most 5000.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/admin/groups-get-groups-as-admin
+func (c *Client) GetGroupsAsAdmin(ctx context.Context, filter string, top int, skip int) (*models.Groups, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/admin/groups
+
+	var err error
+	groups := &models.Groups{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetGroupsAsAdmin: %w", err)
+	}
+
+	if filter != "" {
+		client.SetQueryParam("$filter", filter)
+	}
+	client.SetQueryParam("$top", strconv.Itoa(top))
+	if skip > 0 {
+		client.SetQueryParam("$skip", strconv.Itoa(skip))
+	}
+
+	resp, err := client.SetResult(&groups).Get("/v1.0/myorg/admin/groups")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups as admin: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get groups as admin: %w", newError(resp))
+	}
+
+	return groups, nil
+}
+
+// GetGroupUsersAsAdmin retrieves the users of a workspace through the admin API, which also returns their identifier
+// in Microsoft Graph. The caller must be a Fabric administrator, but needs no access to the workspace.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/admin/groups-get-group-users-as-admin
+func (c *Client) GetGroupUsersAsAdmin(ctx context.Context, groupId string) (*models.GroupUsers, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/admin/groups/{groupId}/users
+
+	var err error
+	groupUsers := &models.GroupUsers{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetGroupUsersAsAdmin: %w", err)
+	}
+
+	resp, err := client.SetResult(groupUsers).Get(fmt.Sprintf("/v1.0/myorg/admin/groups/%s/users", groupId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group users as admin: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get group users as admin: %w", newError(resp))
+	}
+
+	return groupUsers, nil
+}
+
+// RestoreDeletedGroupAsAdmin restores a deleted workspace, giving it a new admin and optionally a new name.
+// The caller must be a Fabric administrator.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/admin/groups-restore-deleted-group-as-admin
+func (c *Client) RestoreDeletedGroupAsAdmin(ctx context.Context, groupId string, groupRestoreRequest *models.GroupRestoreRequest) error {
+	// POST https://api.powerbi.com/v1.0/myorg/admin/groups/{groupId}/restore
+
+	var err error
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare the request for RestoreDeletedGroupAsAdmin: %w", err)
+	}
+
+	resp, err := client.
+		SetBody(groupRestoreRequest).
+		Post(fmt.Sprintf("/v1.0/myorg/admin/groups/%s/restore", groupId))
+	if err != nil {
+		return fmt.Errorf("failed to restore group: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to restore group: %w", newError(resp))
+	}
+
+	return nil
+}
+
+// UpdateGroupLogAnalyticsWorkspaceAsAdmin assigns a Log Analytics workspace to a workspace, or unassigns it when
+// logAnalyticsWorkspace is nil. The workspace must be on a dedicated capacity.
+// The caller must be a Fabric administrator, the Log Analytics workspace cannot be changed through the groups API.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/admin/groups-update-group-as-admin
+func (c *Client) UpdateGroupLogAnalyticsWorkspaceAsAdmin(ctx context.Context, groupId string, logAnalyticsWorkspace *models.AzureResource) error {
+	// PATCH https://api.powerbi.com/v1.0/myorg/admin/groups/{groupId}
+
+	var err error
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare the request for UpdateGroupLogAnalyticsWorkspaceAsAdmin: %w", err)
+	}
+
+	resp, err := client.
+		SetBody(models.UpdateGroupAsAdminLogAnalyticsWorkspace{Id: groupId, LogAnalyticsWorkspace: logAnalyticsWorkspace}).
+		Patch(fmt.Sprintf("/v1.0/myorg/admin/groups/%s", groupId))
+	if err != nil {
+		return fmt.Errorf("failed to update log analytics workspace as admin: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to update log analytics workspace as admin: %w", newError(resp))
+	}
+
+	return nil
+}
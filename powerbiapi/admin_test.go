@@ -0,0 +1,77 @@
+package powerbiapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// TestGetGroupsAsAdmin is a unit test function that tests the GetGroupsAsAdmin method of the Client struct.
+func TestGetGroupsAsAdmin(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	server.PutGroup(fake.Group{Name: "SALES"})
+	deleted := server.PutDeletedGroup(fake.Group{Name: "FINANCE"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	groups, err := client.GetGroupsAsAdmin(ctx, "state eq 'Deleted'", 100, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, groups.Value, 1)
+	assert.Equal(t, deleted.Id, groups.Value[0].Id)
+	assert.Equal(t, models.GroupStateDeleted, groups.Value[0].State)
+	assert.Contains(t, server.Requests()[0].Query, "%24top=100")
+}
+
+// TestGetGroupUsersAsAdmin is a unit test function that tests the GetGroupUsersAsAdmin method of the Client struct,
+// which returns the Graph IDs the regular API leaves out.
+func TestGetGroupUsersAsAdmin(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "SALES"})
+	server.PutGroupUser(group.Id, fake.GroupUser{Identifier: "john.doe@example.com", EmailAddress: "john.doe@example.com", GroupUserAccessRight: "Admin", PrincipalType: "User", GraphId: "0c5e4a5f-9a3b-4a8e-8f3a-2b1c0d9e8f7a"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	users, err := client.GetGroupUsersAsAdmin(ctx, group.Id)
+	assert.NoError(t, err)
+	if assert.Len(t, users.Value, 1) {
+		assert.Equal(t, "0c5e4a5f-9a3b-4a8e-8f3a-2b1c0d9e8f7a", users.Value[0].GraphId)
+	}
+
+	users, err = client.GetGroupUsers(ctx, group.Id)
+	assert.NoError(t, err)
+	if assert.Len(t, users.Value, 1) {
+		assert.Empty(t, users.Value[0].GraphId)
+	}
+
+	_, err = client.GetGroupUsersAsAdmin(ctx, "f089354e-8366-4e18-aea3-4cb4a3a50b48")
+	assert.True(t, IsNotFound(err))
+}
+
+// TestRestoreDeletedGroupAsAdmin is a unit test function that tests the RestoreDeletedGroupAsAdmin method of the Client struct.
+func TestRestoreDeletedGroupAsAdmin(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	deleted := server.PutDeletedGroup(fake.Group{Name: "FINANCE"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	err = client.RestoreDeletedGroupAsAdmin(ctx, deleted.Id, &models.GroupRestoreRequest{EmailAddress: "john.doe@example.com", Name: "FINANCE (restored)"})
+
+	assert.NoError(t, err)
+	group, ok := server.Group(deleted.Id)
+	assert.True(t, ok)
+	assert.Equal(t, "FINANCE (restored)", group.Name)
+
+	err = client.RestoreDeletedGroupAsAdmin(ctx, deleted.Id, &models.GroupRestoreRequest{EmailAddress: "john.doe@example.com"})
+	assert.True(t, IsNotFound(err))
+}
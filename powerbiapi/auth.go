@@ -0,0 +1,77 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// scopes - Power BI API scopes.
+var scopes = []string{"https://analysis.windows.net/powerbi/api/.default"}
+
+// fabricScopes - Fabric API scopes.
+var fabricScopes = []string{"https://api.fabric.microsoft.com/.default"}
+
+// graphScopes - Microsoft Graph scopes.
+var graphScopes = []string{"https://graph.microsoft.com/.default"}
+
+// Authenticate - Authenticates the client.
+func (c *Client) Authenticate() error {
+	creds, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	c.Credentials = creds
+
+	return nil
+}
+
+// GetToken retrieves an access token for the Power BI API.
+// It uses the client credentials, the default Azure credentials when none are set, to obtain the token.
+// Returns the access token as a string or an error if the token retrieval fails.
+func (c *Client) GetToken(ctx context.Context) (string, error) {
+	return c.getToken(ctx, scopes)
+}
+
+// getToken retrieves an access token for the given scopes.
+func (c *Client) getToken(ctx context.Context, scopes []string) (string, error) {
+
+	var err error
+
+	creds := c.Credentials
+	if creds == nil {
+		err = c.Authenticate()
+		if err != nil {
+			return "", fmt.Errorf("failed to authenticate: %w", err)
+		}
+		creds = c.Credentials
+	}
+
+	token, err := creds.GetToken(ctx, policy.TokenRequestOptions{Scopes: scopes})
+	if err != nil {
+		return "", fmt.Errorf("failed to get token: %w", err)
+	}
+
+	return token.Token, nil
+}
+
+// StaticTokenCredential is a credential that always returns the same access token.
+// It is meant for tests and for callers that already hold a token, it never refreshes it.
+type StaticTokenCredential struct {
+	token string
+}
+
+// NewStaticTokenCredential returns a credential that always returns the given access token.
+func NewStaticTokenCredential(token string) *StaticTokenCredential {
+	return &StaticTokenCredential{token: token}
+}
+
+// GetToken implements azcore.TokenCredential.
+func (s *StaticTokenCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: s.token, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
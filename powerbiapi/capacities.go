@@ -0,0 +1,90 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// AssignToCapacity assigns a group to a capacity. The assignment is asynchronous, see WaitForCapacityAssignment.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/capacities/groups-assign-to-capacity
+func (c *Client) AssignToCapacity(ctx context.Context, groupId string, capacityId string) error {
+	// POST https://api.powerbi.com/v1.0/myorg/groups/{groupId}/AssignToCapacity
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare the request for AssignToCapacity: %w", err)
+	}
+
+	resp, err := client.
+		SetBody(models.AssignToCapacityRequest{CapacityId: capacityId}).
+		Post(fmt.Sprintf("/v1.0/myorg/groups/%s/AssignToCapacity", groupId))
+	if err != nil {
+		return fmt.Errorf("failed to assign group to capacity: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to assign group to capacity: %w", newError(resp))
+	}
+
+	return nil
+}
+
+// UnassignFromCapacity unassigns a group from its capacity. The unassignment is asynchronous, see WaitForCapacityAssignment.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/capacities/groups-assign-to-capacity
+func (c *Client) UnassignFromCapacity(ctx context.Context, groupId string) error {
+	return c.AssignToCapacity(ctx, groupId, EmptyId)
+}
+
+// GetCapacityAssignmentStatus retrieves the status of the last assignment to capacity of a group.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/capacities/groups-capacity-assignment-status
+func (c *Client) GetCapacityAssignmentStatus(ctx context.Context, groupId string) (*models.WorkspaceCapacityAssignmentStatus, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/groups/{groupId}/CapacityAssignmentStatus
+
+	status := &models.WorkspaceCapacityAssignmentStatus{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetCapacityAssignmentStatus: %w", err)
+	}
+
+	resp, err := client.SetResult(status).Get(fmt.Sprintf("/v1.0/myorg/groups/%s/CapacityAssignmentStatus", groupId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capacity assignment status: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get capacity assignment status: %w", newError(resp))
+	}
+
+	return status, nil
+}
+
+// WaitForCapacityAssignment polls the capacity assignment status of a group every interval, until the
+// assignment completes successfully or fails. It gives up when ctx is done, so callers bound the wait with a deadline.
+func (c *Client) WaitForCapacityAssignment(ctx context.Context, groupId string, interval time.Duration) (*models.WorkspaceCapacityAssignmentStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.GetCapacityAssignmentStatus(ctx, groupId)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status.Status {
+		case models.AssignmentStatusCompletedSuccessfully:
+			return status, nil
+		case models.AssignmentStatusAssignmentFailed:
+			return status, fmt.Errorf("capacity assignment of group %s failed, activity ID %s", groupId, status.ActivityId)
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("capacity assignment of group %s is still %s: %w", groupId, status.Status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
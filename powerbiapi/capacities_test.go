@@ -0,0 +1,113 @@
+package powerbiapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// TestAssignToCapacity is a unit test function that tests the AssignToCapacity method of the Client struct.
+// It verifies that the correct request URL, method and body are used, and that the function returns no error.
+func TestAssignToCapacity(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1.0/myorg/groups/65d6aaca-2275-4e70-bb4f-91dde4dc6c99/AssignToCapacity", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var body models.AssignToCapacityRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "0f084df7-c13d-451b-af5f-ed0c466403b2", body.CapacityId)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	err = client.AssignToCapacity(ctx, "65d6aaca-2275-4e70-bb4f-91dde4dc6c99", "0f084df7-c13d-451b-af5f-ed0c466403b2")
+
+	assert.NoError(t, err)
+}
+
+// TestWaitForCapacityAssignment is a unit test function that tests the capacity assignment round trip against
+// the fake server: the status is polled until the assignment completes, and unassigning moves the workspace back.
+func TestWaitForCapacityAssignment(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	capacity := server.PutCapacity(fake.Capacity{DisplayName: "F2"})
+	group := server.PutGroup(fake.Group{Name: "CAPACITY"})
+	server.DelayCapacityAssignments(2)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.AssignToCapacity(ctx, group.Id, capacity.Id))
+
+	status, err := client.WaitForCapacityAssignment(ctx, group.Id, time.Millisecond)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AssignmentStatusCompletedSuccessfully, status.Status)
+	assert.Equal(t, capacity.Id, status.CapacityId)
+
+	workspace, err := client.GetGroup(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, capacity.Id, workspace.CapacityId)
+	assert.True(t, workspace.IsOnDedicatedCapacity)
+
+	assert.NoError(t, client.UnassignFromCapacity(ctx, group.Id))
+	_, err = client.WaitForCapacityAssignment(ctx, group.Id, time.Millisecond)
+	assert.NoError(t, err)
+
+	workspace, err = client.GetGroup(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, workspace.CapacityId)
+	assert.False(t, workspace.IsOnDedicatedCapacity)
+}
+
+// TestWaitForCapacityAssignment_Failed is a unit test function that verifies that a failed assignment,
+// here to an unknown capacity, is reported as an error.
+func TestWaitForCapacityAssignment_Failed(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "CAPACITY"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.AssignToCapacity(ctx, group.Id, "0f084df7-c13d-451b-af5f-ed0c466403b2"))
+
+	status, err := client.WaitForCapacityAssignment(ctx, group.Id, time.Millisecond)
+
+	assert.ErrorContains(t, err, "failed")
+	assert.Equal(t, models.AssignmentStatusAssignmentFailed, status.Status)
+}
+
+// TestWaitForCapacityAssignment_Deadline is a unit test function that verifies that polling stops
+// when the context is done before the assignment completes.
+func TestWaitForCapacityAssignment_Deadline(t *testing.T) {
+	server := fake.NewTestServer(t)
+	capacity := server.PutCapacity(fake.Capacity{DisplayName: "F2"})
+	group := server.PutGroup(fake.Group{Name: "CAPACITY"})
+	server.DelayCapacityAssignments(1000)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.AssignToCapacity(context.Background(), group.Id, capacity.Id))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.WaitForCapacityAssignment(ctx, group.Id, 10*time.Millisecond)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,136 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/go-resty/resty/v2"
+)
+
+// BaseURL - Default Power BI URL.
+const BaseURL string = "https://api.powerbi.com"
+
+// FabricBaseURL - Default Fabric URL.
+// The Fabric API manages the workspace properties the Power BI API does not expose, such as the description.
+const FabricBaseURL string = "https://api.fabric.microsoft.com"
+
+// GraphBaseURL - Default Microsoft Graph URL.
+// Microsoft Graph resolves the users, groups and service principals granted access to the workspaces.
+const GraphBaseURL string = "https://graph.microsoft.com"
+
+// EmptyId - The empty GUID. Assigning it as a workspace capacity or dataflow storage account unassigns the workspace,
+// moving it back to the shared capacity or to the default storage.
+const EmptyId string = "00000000-0000-0000-0000-000000000000"
+
+// Client - Power BI API client.
+type Client struct {
+	BaseURL     string
+	FabricURL   string // The Fabric API base URL, empty when the Fabric API is not available.
+	GraphURL    string // The Microsoft Graph base URL, empty when Microsoft Graph is not available.
+	RestyClient *resty.Client
+	Credentials azcore.TokenCredential
+}
+
+// maxRetries - Number of times a throttled or failed request is retried.
+const maxRetries = 3
+
+// NewClient creates a new instance of the Client struct.
+// It takes a host URL as a parameter and returns a pointer to the Client and an error.
+// If the host URL is provided, it will be used as the BaseURL for the Client.
+// If the host URL is not provided, the default BaseURL will be used.
+// The Fabric API and Microsoft Graph are only used with the default BaseURL, set FabricURL and GraphURL
+// to use them with another host.
+// It also retrieves a token using the GetToken method and assigns it to the Client's Token field.
+// If an error occurs while retrieving the token, an error is returned.
+func NewClient(host string) (*Client, error) {
+
+	var err error
+
+	c := Client{
+		BaseURL:     BaseURL,
+		FabricURL:   FabricBaseURL,
+		GraphURL:    GraphBaseURL,
+		RestyClient: resty.New(),
+	}
+
+	if host != "" {
+		c.BaseURL = host
+		c.FabricURL = ""
+		c.GraphURL = ""
+	}
+
+	c.RestyClient.SetBaseURL(c.BaseURL).
+		SetRetryCount(maxRetries).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(
+			func(r *resty.Response, err error) bool {
+				// Including "err != nil" emulates the default retry behavior for errors encountered during the request.
+				return err != nil || r.StatusCode() == http.StatusTooManyRequests
+			},
+		)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate the client: %v", err)
+	}
+
+	return &c, nil
+}
+
+// prepRequest - Prepares a request for the Power BI API.
+// It sets the global request parameters and returns a pointer to a resty.Request.
+// The request is bound to ctx, so cancelling ctx cancels the call and its retries.
+// It returns a pointer to a resty.Request and an error.
+func (c *Client) prepRequest(ctx context.Context) (*resty.Request, error) {
+	token, err := c.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token while preparing the request: %w", err)
+	}
+	return c.RestyClient.R().SetContext(ctx).SetAuthToken(token).SetError(&errorResponse{}), nil
+}
+
+// prepFabricRequest - Prepares a request for the Fabric API, like prepRequest with a token for the Fabric API.
+// The request URLs are absolute, built on FabricURL rather than on the base URL of the resty client.
+func (c *Client) prepFabricRequest(ctx context.Context) (*resty.Request, error) {
+	if c.FabricURL == "" {
+		return nil, ErrFabricUnavailable
+	}
+
+	token, err := c.getToken(ctx, fabricScopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token while preparing the request: %w", err)
+	}
+	return c.RestyClient.R().SetContext(ctx).SetAuthToken(token).SetError(&errorResponse{}), nil
+}
+
+// prepGraphRequest - Prepares a request for Microsoft Graph, like prepRequest with a token for Microsoft Graph.
+// The request URLs are absolute, built on GraphURL rather than on the base URL of the resty client.
+func (c *Client) prepGraphRequest(ctx context.Context) (*resty.Request, error) {
+	if c.GraphURL == "" {
+		return nil, ErrGraphUnavailable
+	}
+
+	token, err := c.getToken(ctx, graphScopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token while preparing the request: %w", err)
+	}
+	return c.RestyClient.R().SetContext(ctx).SetAuthToken(token).SetError(&errorResponse{}), nil
+}
+
+// retryAfter - Honors the Retry-After header sent by the Power BI API when a request is throttled.
+// Returning zero lets resty fall back to its default exponential backoff.
+func retryAfter(_ *resty.Client, r *resty.Response) (time.Duration, error) {
+	if r == nil {
+		return 0, nil
+	}
+
+	seconds, err := strconv.Atoi(r.Header().Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0, nil
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
@@ -0,0 +1,78 @@
+package powerbiapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// newTestClient creates a client for the given host that authenticates with the fake server token,
+// so unit tests never reach for the Azure credential chain.
+func newTestClient(host string) (*Client, error) {
+	client, err := NewClient(host)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Credentials = NewStaticTokenCredential(fake.Token)
+	// The fake server also serves the Fabric API and Microsoft Graph.
+	client.FabricURL = host
+	client.GraphURL = host
+
+	return client, nil
+}
+
+// TestClient_RetriesThrottledRequests checks that requests throttled by the service are retried
+// until they succeed, as long as the retry budget is not exhausted.
+func TestClient_RetriesThrottledRequests(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	server.Throttle(2, 0)
+
+	group, err := client.CreateGroup(ctx, "THROTTLED")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "THROTTLED", group.Name)
+	assert.Len(t, server.Requests(), 3)
+}
+
+// TestClient_GivesUpOnPersistentThrottling checks that a request throttled more times than the
+// retry budget fails instead of retrying forever.
+func TestClient_GivesUpOnPersistentThrottling(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	server.Throttle(maxRetries+1, time.Second)
+
+	_, err = client.GetGroups(ctx, "", 0, 0)
+
+	assert.Error(t, err)
+	assert.Len(t, server.Requests(), maxRetries+1)
+}
+
+// TestClient_RejectsMissingToken checks that the fake server, like the service, rejects unauthenticated calls.
+func TestClient_RejectsMissingToken(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+
+	client, err := NewClient(server.URL)
+	assert.NoError(t, err)
+	client.Credentials = NewStaticTokenCredential("not-the-fake-token")
+
+	_, err = client.GetGroups(ctx, "", 0, 0)
+
+	assert.Error(t, err)
+	assert.Equal(t, http.MethodGet, server.Requests()[0].Method)
+}
@@ -0,0 +1,32 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// GetDashboardsInGroup retrieves the dashboards of a group.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/dashboards/get-dashboards-in-group
+func (c *Client) GetDashboardsInGroup(ctx context.Context, groupId string) (*models.Dashboards, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/groups/{groupId}/dashboards
+
+	dashboards := &models.Dashboards{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetDashboardsInGroup: %w", err)
+	}
+
+	resp, err := client.SetResult(dashboards).Get(fmt.Sprintf("/v1.0/myorg/groups/%s/dashboards", groupId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group dashboards: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get group dashboards: %w", newError(resp))
+	}
+
+	return dashboards, nil
+}
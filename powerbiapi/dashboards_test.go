@@ -0,0 +1,28 @@
+package powerbiapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestGetDashboardsInGroup is a unit test function that tests the GetDashboardsInGroup method of the Client struct.
+func TestGetDashboardsInGroup(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "SALES"})
+	dashboard := server.PutDashboard(group.Id, fake.Dashboard{DisplayName: "Overview"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	dashboards, err := client.GetDashboardsInGroup(ctx, group.Id)
+
+	assert.NoError(t, err)
+	assert.Len(t, dashboards.Value, 1)
+	assert.Equal(t, dashboard.Id, dashboards.Value[0].Id)
+	assert.Equal(t, "Overview", dashboards.Value[0].DisplayName)
+}
@@ -0,0 +1,62 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// GetDataflowStorageAccounts retrieves the dataflow storage accounts the user has access to.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/dataflow-storage-accounts/get-dataflow-storage-accounts
+func (c *Client) GetDataflowStorageAccounts(ctx context.Context) (*models.DataflowStorageAccounts, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/dataflowStorageAccounts
+
+	accounts := &models.DataflowStorageAccounts{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetDataflowStorageAccounts: %w", err)
+	}
+
+	resp, err := client.SetResult(accounts).Get("/v1.0/myorg/dataflowStorageAccounts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dataflow storage accounts: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get dataflow storage accounts: %w", newError(resp))
+	}
+
+	return accounts, nil
+}
+
+// AssignToDataflowStorage assigns a group to a dataflow storage account.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/dataflow-storage-accounts/groups-assign-to-dataflow-storage
+func (c *Client) AssignToDataflowStorage(ctx context.Context, groupId string, dataflowStorageId string) error {
+	// POST https://api.powerbi.com/v1.0/myorg/groups/{groupId}/AssignToDataflowStorage
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare the request for AssignToDataflowStorage: %w", err)
+	}
+
+	resp, err := client.
+		SetBody(models.AssignToDataflowStorageRequest{DataflowStorageId: dataflowStorageId}).
+		Post(fmt.Sprintf("/v1.0/myorg/groups/%s/AssignToDataflowStorage", groupId))
+	if err != nil {
+		return fmt.Errorf("failed to assign group to dataflow storage: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to assign group to dataflow storage: %w", newError(resp))
+	}
+
+	return nil
+}
+
+// UnassignFromDataflowStorage unassigns a group from its dataflow storage account.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/dataflow-storage-accounts/groups-assign-to-dataflow-storage
+func (c *Client) UnassignFromDataflowStorage(ctx context.Context, groupId string) error {
+	return c.AssignToDataflowStorage(ctx, groupId, EmptyId)
+}
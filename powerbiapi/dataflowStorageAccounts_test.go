@@ -0,0 +1,72 @@
+package powerbiapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestGetDataflowStorageAccounts is a unit test function that tests the GetDataflowStorageAccounts method of the Client struct.
+// It verifies that the accounts registered in the tenant are listed.
+func TestGetDataflowStorageAccounts(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "lake", IsEnabled: true})
+	server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "archive"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	accounts, err := client.GetDataflowStorageAccounts(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, accounts.Value, 2)
+	assert.Equal(t, "lake", accounts.Value[0].Name)
+	assert.True(t, accounts.Value[0].IsEnabled)
+	assert.False(t, accounts.Value[1].IsEnabled)
+}
+
+// TestAssignToDataflowStorage is a unit test function that tests the AssignToDataflowStorage and
+// UnassignFromDataflowStorage methods of the Client struct against the fake server.
+func TestAssignToDataflowStorage(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	account := server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "lake", IsEnabled: true})
+	group := server.PutGroup(fake.Group{Name: "DATAFLOWS"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.AssignToDataflowStorage(ctx, group.Id, account.Id))
+
+	workspace, err := client.GetGroup(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, account.Id, workspace.DataflowStorageId)
+
+	assert.NoError(t, client.UnassignFromDataflowStorage(ctx, group.Id))
+
+	workspace, err = client.GetGroup(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, workspace.DataflowStorageId)
+}
+
+// TestAssignToDataflowStorage_Disabled is a unit test function that verifies that assigning a workspace
+// to a disabled dataflow storage account fails with the error returned by the service.
+func TestAssignToDataflowStorage_Disabled(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	account := server.PutDataflowStorageAccount(fake.DataflowStorageAccount{Name: "archive"})
+	group := server.PutGroup(fake.Group{Name: "DATAFLOWS"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	err = client.AssignToDataflowStorage(ctx, group.Id, account.Id)
+
+	assert.True(t, HasStatusCode(err, http.StatusBadRequest))
+	assert.True(t, HasErrorCode(err, "DataflowStorageAccountDisabled"))
+}
@@ -0,0 +1,32 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// GetDataflows retrieves the dataflows of a group.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/dataflows/get-dataflows
+func (c *Client) GetDataflows(ctx context.Context, groupId string) (*models.Dataflows, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/groups/{groupId}/dataflows
+
+	dataflows := &models.Dataflows{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetDataflows: %w", err)
+	}
+
+	resp, err := client.SetResult(dataflows).Get(fmt.Sprintf("/v1.0/myorg/groups/%s/dataflows", groupId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group dataflows: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get group dataflows: %w", newError(resp))
+	}
+
+	return dataflows, nil
+}
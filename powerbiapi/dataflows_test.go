@@ -0,0 +1,28 @@
+package powerbiapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestGetDataflows is a unit test function that tests the GetDataflows method of the Client struct.
+func TestGetDataflows(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "SALES"})
+	dataflow := server.PutDataflow(group.Id, fake.Dataflow{Name: "Orders"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	dataflows, err := client.GetDataflows(ctx, group.Id)
+
+	assert.NoError(t, err)
+	assert.Len(t, dataflows.Value, 1)
+	assert.Equal(t, dataflow.ObjectId, dataflows.Value[0].ObjectId)
+	assert.Equal(t, "Orders", dataflows.Value[0].Name)
+}
@@ -0,0 +1,32 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// GetDatasetsInGroup retrieves the datasets of a group.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-datasets-in-group
+func (c *Client) GetDatasetsInGroup(ctx context.Context, groupId string) (*models.Datasets, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/groups/{groupId}/datasets
+
+	datasets := &models.Datasets{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetDatasetsInGroup: %w", err)
+	}
+
+	resp, err := client.SetResult(datasets).Get(fmt.Sprintf("/v1.0/myorg/groups/%s/datasets", groupId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group datasets: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get group datasets: %w", newError(resp))
+	}
+
+	return datasets, nil
+}
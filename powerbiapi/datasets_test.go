@@ -0,0 +1,28 @@
+package powerbiapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestGetDatasetsInGroup is a unit test function that tests the GetDatasetsInGroup method of the Client struct.
+func TestGetDatasetsInGroup(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "SALES"})
+	dataset := server.PutDataset(group.Id, fake.Dataset{Name: "Sales", IsRefreshable: true})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	datasets, err := client.GetDatasetsInGroup(ctx, group.Id)
+
+	assert.NoError(t, err)
+	assert.Len(t, datasets.Value, 1)
+	assert.Equal(t, dataset.Id, datasets.Value[0].Id)
+	assert.True(t, datasets.Value[0].IsRefreshable)
+}
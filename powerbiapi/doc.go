@@ -0,0 +1,40 @@
+// Package powerbiapi is a Go client for the Power BI REST API, shared by the Terraform provider and by
+// Go tooling working on the same tenants.
+//
+// A Client authenticates with an azcore.TokenCredential, the Azure default credential chain unless
+// Client.Credentials is set, and retries throttled requests honoring their Retry-After header.
+// Every method takes a context, which bounds the call, its retries and the token acquisition.
+//
+//	client, err := powerbiapi.NewClient("")
+//	if err != nil {
+//		return err
+//	}
+//
+//	group, err := client.GetGroup(ctx, groupId)
+//	if powerbiapi.IsNotFound(err) {
+//		// The workspace does not exist, or the caller has no access to it.
+//	}
+//
+// Failed calls return an error wrapping an *Error, which holds the HTTP status and the Power BI error
+// code and message. IsNotFound, IsAlreadyExists, HasStatusCode and HasErrorCode inspect it without a type assertion.
+//
+// The workspace properties the Power BI REST API does not expose, such as the description, go through
+// the Fabric REST API at Client.FabricURL. It is only set for the default Power BI host, the operations
+// needing it return ErrFabricUnavailable otherwise.
+//
+// Likewise, the users, groups and service principals are looked up in Microsoft Graph at Client.GraphURL,
+// with GetGraphUser, ListGraphGroups and ListGraphServicePrincipals, which return ErrGraphUnavailable without it.
+// The caller needs the User.Read.All, GroupMember.Read.All and Application.Read.All permissions, or Directory.Read.All.
+//
+// List operations which the service pages, such as the workspaces of the tenant, have a Pager:
+// see NewGroupsPager, NewGroupUsersPager and NewAdminGroupsPager.
+//
+// The admin operations, such as GetGroupsAsAdmin and RestoreDeletedGroupAsAdmin, require the caller to be
+// a Fabric administrator, or a service principal allowed to use the read-only admin APIs for the listings.
+//
+// The request and response types live in the models subpackage, generated from the Power BI, Fabric and
+// Microsoft Graph REST API specifications. The fake subpackage is an in-memory Power BI service for tests.
+//
+// The exported API of this package and its subpackages follows the semantic versioning of the provider
+// releases: it only changes in a backward incompatible way in a major release.
+package powerbiapi
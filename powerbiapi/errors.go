@@ -0,0 +1,100 @@
+package powerbiapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Error is returned when the Power BI API answers with an error status.
+// The client wraps it with the failed operation, use errors.As to retrieve it.
+type Error struct {
+	StatusCode int    // The HTTP status code of the response
+	Code       string // The Power BI or Fabric error code, such as PowerBIEntityNotFound, when the response carries one
+	Message    string // The Power BI error message, or the raw response body
+	Method     string // The method of the failed request
+	URL        string // The URL of the failed request
+}
+
+// errorResponse is the error payload returned by the Power BI API, or by the Fabric API
+// which carries the error code and message at the top level.
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	status := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+
+	switch {
+	case e.Code != "" && e.Message != "":
+		return fmt.Sprintf("%s %s: %s: %s: %s", e.Method, e.URL, status, e.Code, e.Message)
+	case e.Code != "":
+		return fmt.Sprintf("%s %s: %s: %s", e.Method, e.URL, status, e.Code)
+	case e.Message != "":
+		return fmt.Sprintf("%s %s: %s: %s", e.Method, e.URL, status, e.Message)
+	default:
+		return fmt.Sprintf("%s %s: %s", e.Method, e.URL, status)
+	}
+}
+
+// newError builds the Error of a failed response.
+func newError(resp *resty.Response) *Error {
+	e := &Error{
+		StatusCode: resp.StatusCode(),
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL,
+	}
+
+	payload, ok := resp.Error().(*errorResponse)
+	switch {
+	case ok && payload.Error.Code != "":
+		e.Code = payload.Error.Code
+		e.Message = payload.Error.Message
+	case ok && payload.ErrorCode != "":
+		e.Code = payload.ErrorCode
+		e.Message = payload.Message
+	default:
+		e.Message = resp.String()
+	}
+
+	return e
+}
+
+// IsNotFound tells whether err was caused by a 404 Not Found response, for instance because the requested
+// workspace or pipeline does not exist or is not visible to the caller.
+func IsNotFound(err error) bool {
+	return HasStatusCode(err, http.StatusNotFound)
+}
+
+// IsAlreadyExists tells whether err was caused by the creation of an object whose name is already taken,
+// for instance a workspace named like an existing one.
+func IsAlreadyExists(err error) bool {
+	return HasErrorCode(err, "PowerBIEntityAlreadyExists")
+}
+
+// IsUnauthorized tells whether err was caused by a 401 Unauthorized or a 403 Forbidden response,
+// when the caller is not authenticated or not allowed to call the API.
+func IsUnauthorized(err error) bool {
+	return HasStatusCode(err, http.StatusUnauthorized) || HasStatusCode(err, http.StatusForbidden)
+}
+
+// HasStatusCode tells whether err was caused by a response with the given status code.
+func HasStatusCode(err error, statusCode int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == statusCode
+}
+
+// HasErrorCode tells whether err was caused by a response with the given Power BI error code,
+// such as PowerBIEntityAlreadyExists.
+func HasErrorCode(err error, code string) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == code
+}
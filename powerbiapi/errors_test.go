@@ -0,0 +1,109 @@
+package powerbiapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestError_NotFound is a unit test function that tests the typed error returned for a missing workspace.
+func TestError_NotFound(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	_, err = client.GetGroup(ctx, "a1ee5fd6-1bd1-4b8e-9db4-0d3b8c8c4a2e")
+
+	assert.True(t, IsNotFound(err))
+	assert.True(t, HasErrorCode(err, "PowerBIEntityNotFound"))
+
+	var apiErr *Error
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, http.MethodGet, apiErr.Method)
+		assert.Equal(t, server.URL+"/v1.0/myorg/groups/a1ee5fd6-1bd1-4b8e-9db4-0d3b8c8c4a2e", apiErr.URL)
+	}
+	assert.Contains(t, err.Error(), "404 Not Found: PowerBIEntityNotFound")
+}
+
+// TestError_Conflict is a unit test function that tests the Power BI error code of a duplicate workspace name.
+func TestError_Conflict(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	server.PutGroup(fake.Group{Name: "TF_DUPLICATE"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	_, err = client.CreateGroup(ctx, "TF_DUPLICATE")
+
+	assert.False(t, IsNotFound(err))
+	assert.True(t, HasStatusCode(err, http.StatusConflict))
+	assert.True(t, HasErrorCode(err, "PowerBIEntityAlreadyExists"))
+	assert.True(t, IsAlreadyExists(err))
+}
+
+// TestError_Forbidden is a unit test function that tests the error returned when the Fabric API denies access.
+func TestError_Forbidden(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "TF_FORBIDDEN"})
+	server.ForbidFabric(true)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	_, err = client.GetGroupDescription(ctx, group.Id)
+
+	assert.True(t, IsUnauthorized(err))
+	assert.True(t, HasErrorCode(err, "InsufficientScopes"))
+	assert.False(t, IsNotFound(err))
+}
+
+// TestError_NotJSON is a unit test function that tests an error response without a Power BI error payload.
+func TestError_NotJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream unavailable")
+	}))
+	defer server.Close()
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+	client.RestyClient.SetRetryCount(0)
+
+	err = client.DeleteGroup(context.Background(), "a1ee5fd6-1bd1-4b8e-9db4-0d3b8c8c4a2e")
+
+	var apiErr *Error
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.Empty(t, apiErr.Code)
+		assert.Equal(t, "upstream unavailable", apiErr.Message)
+	}
+}
+
+// TestContext_Cancelled is a unit test function that tests a cancelled context stops the call.
+func TestContext_Cancelled(t *testing.T) {
+	server := fake.NewTestServer(t)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.GetGroups(ctx, "", 0, 0)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, server.Requests())
+}
@@ -0,0 +1,62 @@
+package powerbiapi_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// This example lists the workspaces whose name starts with "Sales", page by page.
+func ExampleClient_NewGroupsPager() {
+	server := fake.NewServer()
+	defer server.Close()
+	server.PutGroup(fake.Group{Name: "Sales EMEA"})
+	server.PutGroup(fake.Group{Name: "Finance"})
+	server.PutGroup(fake.Group{Name: "Sales APAC"})
+
+	client, err := powerbiapi.NewClient(server.URL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client.Credentials = powerbiapi.NewStaticTokenCredential(fake.Token)
+
+	ctx := context.Background()
+	pager := client.NewGroupsPager(&powerbiapi.GroupsPagerOptions{Filter: "startswith(name,'Sales')"})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, group := range page.Value {
+			fmt.Println(group.Name)
+		}
+	}
+	// Output:
+	// Sales EMEA
+	// Sales APAC
+}
+
+// This example tells a missing workspace from other failures.
+func ExampleIsNotFound() {
+	server := fake.NewServer()
+	defer server.Close()
+
+	client, err := powerbiapi.NewClient(server.URL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client.Credentials = powerbiapi.NewStaticTokenCredential(fake.Token)
+
+	_, err = client.GetGroup(context.Background(), "3f0e3e52-0e5c-4a0e-a7d5-4c1f5a4cb4b1")
+	switch {
+	case powerbiapi.IsNotFound(err):
+		fmt.Println("the workspace does not exist")
+	case err != nil:
+		log.Fatal(err)
+	}
+	// Output:
+	// the workspace does not exist
+}
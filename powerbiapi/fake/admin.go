@@ -0,0 +1,232 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// adminGroup is a group as returned by the admin API, which also tells its state.
+type adminGroup struct {
+	Group
+	State string `json:"state"`
+}
+
+// adminGroupUser is a group user as returned by the admin API, which also tells its identifier in Microsoft Graph.
+type adminGroupUser struct {
+	GroupUser
+	GraphId string `json:"graphId,omitempty"`
+}
+
+// groupUpdateAsAdminRequest is the body accepted by the admin update group endpoint.
+// LogAnalyticsWorkspace is kept raw, to tell an unassignment, sent as null, from a missing property.
+type groupUpdateAsAdminRequest struct {
+	Id                    string          `json:"id"`
+	LogAnalyticsWorkspace json.RawMessage `json:"logAnalyticsWorkspace"`
+}
+
+// groupRestoreRequest is the body accepted by the restore deleted group endpoint.
+type groupRestoreRequest struct {
+	EmailAddress string `json:"emailAddress"`
+	Name         string `json:"name"`
+}
+
+// PutDeletedGroup stores a deleted group, which only the admin API lists and restores, and returns the stored copy.
+// An ID is generated when none is set.
+func (s *Server) PutDeletedGroup(g Group) Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g.Id == "" {
+		g.Id = newId()
+	}
+	if g.Type == "" {
+		g.Type = "Workspace"
+	}
+
+	s.deletedGroups = append(s.deletedGroups, &g)
+	return g
+}
+
+// DeletedGroups returns a copy of every deleted group, in deletion order.
+func (s *Server) DeletedGroups() []Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := make([]Group, 0, len(s.deletedGroups))
+	for _, g := range s.deletedGroups {
+		groups = append(groups, *g)
+	}
+	return groups
+}
+
+// routeAdmin dispatches the /v1.0/myorg/admin endpoints.
+func (s *Server) routeAdmin(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	switch {
+	case len(segments) == 1 && segments[0] == "groups" && r.Method == http.MethodGet:
+		s.listGroupsAsAdmin(w, r)
+	case len(segments) == 2 && segments[0] == "groups" && r.Method == http.MethodPatch:
+		s.updateGroupAsAdmin(w, segments[1], body)
+	case len(segments) == 3 && segments[0] == "groups" && segments[2] == "users" && r.Method == http.MethodGet:
+		s.listGroupUsersAsAdmin(w, segments[1])
+	case len(segments) == 3 && segments[0] == "groups" && segments[2] == "restore" && r.Method == http.MethodPost:
+		s.restoreDeletedGroup(w, segments[1], body)
+	default:
+		writeNotFound(w, r.URL.Path)
+	}
+}
+
+// listGroupsAsAdmin implements GET /admin/groups with the $filter, $top and $skip query parameters.
+// It lists the active groups, then the deleted ones.
+func (s *Server) listGroupsAsAdmin(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	match, err := parseFilter(query.Get("$filter"), map[string]func(adminGroup) string{
+		"id":    func(g adminGroup) string { return g.Id },
+		"name":  func(g adminGroup) string { return g.Name },
+		"state": func(g adminGroup) string { return g.State },
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return
+	}
+
+	groups := []adminGroup{}
+	for _, g := range s.groups {
+		if group := (adminGroup{Group: *g, State: "Active"}); match(group) {
+			groups = append(groups, group)
+		}
+	}
+	for _, g := range s.deletedGroups {
+		if group := (adminGroup{Group: *g, State: "Deleted"}); match(group) {
+			groups = append(groups, group)
+		}
+	}
+
+	start, end, ok := pageBounds(w, query, len(groups))
+	if !ok {
+		return
+	}
+	groups = groups[start:end]
+
+	writeJSON(w, http.StatusOK, odataList{
+		ODataContext: "http://fake.analysis.windows.net/v1.0/myorg/admin/$metadata#groups",
+		ODataCount:   len(groups),
+		Value:        groups,
+	})
+}
+
+// listGroupUsersAsAdmin implements GET /admin/groups/{groupId}/users.
+// The users of groups and apps have their identifier as Graph ID, unless another one is set.
+func (s *Server) listGroupUsersAsAdmin(w http.ResponseWriter, id string) {
+	if s.findGroup(id) == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	users := []adminGroupUser{}
+	for _, u := range s.users[id] {
+		user := adminGroupUser{GroupUser: u, GraphId: u.GraphId}
+		if user.GraphId == "" && u.PrincipalType != "User" {
+			user.GraphId = u.Identifier
+		}
+		users = append(users, user)
+	}
+
+	writeJSON(w, http.StatusOK, odataList{
+		ODataContext: fmt.Sprintf("http://fake.analysis.windows.net/v1.0/myorg/admin/groups/%s/$metadata#users", id),
+		Value:        users,
+	})
+}
+
+// restoreDeletedGroup implements POST /admin/groups/{groupId}/restore.
+// The restored group gets the requested name, if any, and the requested user as its only admin.
+func (s *Server) restoreDeletedGroup(w http.ResponseWriter, id string, body []byte) {
+	index := -1
+	for i, g := range s.deletedGroups {
+		if strings.EqualFold(g.Id, id) {
+			index = i
+		}
+	}
+	if index < 0 {
+		writeNotFound(w, fmt.Sprintf("Deleted workspace %s", id))
+		return
+	}
+
+	var req groupRestoreRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if strings.TrimSpace(req.EmailAddress) == "" {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", "The email address of the new admin is required")
+		return
+	}
+
+	g := s.deletedGroups[index]
+	name := g.Name
+	if req.Name != "" {
+		name = req.Name
+	}
+
+	if s.findGroupByName(name) != nil {
+		writeError(w, http.StatusConflict, "PowerBIEntityAlreadyExists", fmt.Sprintf("A workspace named %s already exists", name))
+		return
+	}
+
+	g.Name = name
+	s.deletedGroups = append(s.deletedGroups[:index:index], s.deletedGroups[index+1:]...)
+	s.groups = append(s.groups, g)
+	s.putUser(g.Id, GroupUser{
+		EmailAddress:         req.EmailAddress,
+		GroupUserAccessRight: "Admin",
+		Identifier:           req.EmailAddress,
+		PrincipalType:        "User",
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// updateGroupAsAdmin implements PATCH /admin/groups/{groupId}.
+// Only the Log Analytics workspace is updated, which the groups API cannot change. A null one unassigns it.
+func (s *Server) updateGroupAsAdmin(w http.ResponseWriter, id string, body []byte) {
+	g := s.findGroup(id)
+	if g == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	var req groupUpdateAsAdminRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if !strings.EqualFold(req.Id, id) {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("The workspace ID %s does not match the URL", req.Id))
+		return
+	}
+
+	var logAnalytics *AzureResource
+	if req.LogAnalyticsWorkspace != nil && string(req.LogAnalyticsWorkspace) != "null" {
+		if !decodeBody(w, req.LogAnalyticsWorkspace, &logAnalytics) {
+			return
+		}
+		if !g.IsOnDedicatedCapacity {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", "The Log Analytics workspace requires a dedicated capacity")
+			return
+		}
+		if logAnalytics.SubscriptionId == "" || logAnalytics.ResourceGroup == "" || logAnalytics.ResourceName == "" {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", "The Log Analytics workspace must have a subscription, a resource group and a name")
+			return
+		}
+		// The id sent is ignored, the service assigns it.
+		logAnalytics.Id = newId()
+	}
+
+	if req.LogAnalyticsWorkspace != nil {
+		g.LogAnalyticsWorkspace = logAnalytics
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,126 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Capacity is a Premium or Fabric capacity that workspaces can be assigned to.
+type Capacity struct {
+	Id          string
+	DisplayName string
+}
+
+// capacityAssignment is the last assignment to capacity operation of a workspace.
+type capacityAssignment struct {
+	ActivityId string    `json:"activityId"`
+	CapacityId string    `json:"capacityId,omitempty"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Status     string    `json:"status"`
+
+	// polls is the number of status requests answered with InProgress before the operation completes.
+	polls int
+}
+
+// assignToCapacityRequest is the body accepted by the assign to capacity endpoint.
+type assignToCapacityRequest struct {
+	CapacityId string `json:"capacityId"`
+}
+
+// PutCapacity stores a capacity, so workspaces can be assigned to it, and returns the stored copy.
+// An ID is generated when none is set.
+func (s *Server) PutCapacity(c Capacity) Capacity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c.Id == "" {
+		c.Id = newId()
+	}
+	s.capacities[strings.ToLower(c.Id)] = c
+	return c
+}
+
+// DelayCapacityAssignments makes the next assignments to capacity report InProgress for the given number
+// of status requests before they complete, like the asynchronous operation of the service.
+func (s *Server) DelayCapacityAssignments(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.assignmentPolls = polls
+}
+
+// assignToCapacity implements POST /groups/{groupId}/AssignToCapacity.
+// The workspace is moved at once, only the reported status lags. Assigning to an unknown capacity
+// leaves the workspace untouched and reports the AssignmentFailed status.
+func (s *Server) assignToCapacity(w http.ResponseWriter, id string, body []byte) {
+	g := s.findGroup(id)
+	if g == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	var req assignToCapacityRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	now := time.Now().UTC()
+	assignment := &capacityAssignment{
+		ActivityId: newId(),
+		CapacityId: req.CapacityId,
+		StartTime:  now,
+		Status:     "InProgress",
+		polls:      s.assignmentPolls,
+	}
+
+	switch capacity, known := s.capacities[strings.ToLower(req.CapacityId)]; {
+	case req.CapacityId == emptyId:
+		assignment.CapacityId = ""
+		g.CapacityId = ""
+		g.IsOnDedicatedCapacity = false
+		g.DefaultDatasetStorageFormat = ""
+	case known:
+		// The service returns the capacity ID in its own case, whatever the case of the request.
+		g.CapacityId = capacity.Id
+		g.IsOnDedicatedCapacity = true
+		if g.DefaultDatasetStorageFormat == "" {
+			g.DefaultDatasetStorageFormat = "Small"
+		}
+	default:
+		assignment.Status = "AssignmentFailed"
+		assignment.EndTime = now
+		assignment.polls = 0
+	}
+
+	s.assignments[g.Id] = assignment
+	w.WriteHeader(http.StatusOK)
+}
+
+// getCapacityAssignmentStatus implements GET /groups/{groupId}/CapacityAssignmentStatus.
+func (s *Server) getCapacityAssignmentStatus(w http.ResponseWriter, id string) {
+	g := s.findGroup(id)
+	if g == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	assignment, ok := s.assignments[g.Id]
+	if !ok {
+		writeNotFound(w, fmt.Sprintf("Capacity assignment of workspace %s", id))
+		return
+	}
+
+	if assignment.Status == "InProgress" {
+		if assignment.polls > 0 {
+			assignment.polls--
+		} else {
+			assignment.Status = "CompletedSuccessfully"
+			assignment.EndTime = time.Now().UTC()
+		}
+	}
+
+	writeJSON(w, http.StatusOK, assignment)
+}
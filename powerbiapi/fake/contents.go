@@ -0,0 +1,167 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Report is a report published in a group, as stored and returned by the fake server.
+type Report struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	DatasetId  string `json:"datasetId,omitempty"`
+	ReportType string `json:"reportType,omitempty"`
+	WebUrl     string `json:"webUrl,omitempty"`
+}
+
+// Dataset is a dataset published in a group, as stored and returned by the fake server.
+type Dataset struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	ConfiguredBy  string `json:"configuredBy,omitempty"`
+	IsRefreshable bool   `json:"isRefreshable"`
+	WebUrl        string `json:"webUrl,omitempty"`
+}
+
+// Dashboard is a dashboard of a group, as stored and returned by the fake server.
+type Dashboard struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	IsReadOnly  bool   `json:"isReadOnly"`
+	WebUrl      string `json:"webUrl,omitempty"`
+}
+
+// Dataflow is a dataflow of a group, as stored and returned by the fake server.
+type Dataflow struct {
+	ObjectId     string `json:"objectId"`
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	ConfiguredBy string `json:"configuredBy,omitempty"`
+}
+
+// Datamart is a datamart of a group, as stored by the fake server. Only the Fabric items API lists it.
+type Datamart struct {
+	Id          string
+	Name        string
+	Description string
+}
+
+// groupContents holds the artifacts published in a group.
+type groupContents struct {
+	reports    []Report
+	datasets   []Dataset
+	dashboards []Dashboard
+	dataflows  []Dataflow
+	datamarts  []Datamart
+}
+
+// PutReport publishes a report in a group and returns the stored copy. An ID is generated when none is set.
+func (s *Server) PutReport(groupId string, r Report) Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Id == "" {
+		r.Id = newId()
+	}
+	contents := s.groupContents(groupId)
+	contents.reports = append(contents.reports, r)
+	return r
+}
+
+// PutDataset publishes a dataset in a group and returns the stored copy. An ID is generated when none is set.
+func (s *Server) PutDataset(groupId string, d Dataset) Dataset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.Id == "" {
+		d.Id = newId()
+	}
+	contents := s.groupContents(groupId)
+	contents.datasets = append(contents.datasets, d)
+	return d
+}
+
+// PutDashboard adds a dashboard to a group and returns the stored copy. An ID is generated when none is set.
+func (s *Server) PutDashboard(groupId string, d Dashboard) Dashboard {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.Id == "" {
+		d.Id = newId()
+	}
+	contents := s.groupContents(groupId)
+	contents.dashboards = append(contents.dashboards, d)
+	return d
+}
+
+// PutDataflow adds a dataflow to a group and returns the stored copy. An ID is generated when none is set.
+func (s *Server) PutDataflow(groupId string, d Dataflow) Dataflow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.ObjectId == "" {
+		d.ObjectId = newId()
+	}
+	contents := s.groupContents(groupId)
+	contents.dataflows = append(contents.dataflows, d)
+	return d
+}
+
+// PutDatamart adds a datamart to a group and returns the stored copy. An ID is generated when none is set.
+func (s *Server) PutDatamart(groupId string, d Datamart) Datamart {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.Id == "" {
+		d.Id = newId()
+	}
+	contents := s.groupContents(groupId)
+	contents.datamarts = append(contents.datamarts, d)
+	return d
+}
+
+// listGroupContents implements GET /groups/{groupId}/{kind} for the reports, datasets, dashboards and dataflows.
+func (s *Server) listGroupContents(w http.ResponseWriter, id string, kind string) {
+	if s.findGroup(id) == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	contents := s.groupContents(id)
+
+	var value interface{}
+	switch kind {
+	case "reports":
+		value = append([]Report{}, contents.reports...)
+	case "datasets":
+		value = append([]Dataset{}, contents.datasets...)
+	case "dashboards":
+		value = append([]Dashboard{}, contents.dashboards...)
+	case "dataflows":
+		value = append([]Dataflow{}, contents.dataflows...)
+	}
+
+	writeJSON(w, http.StatusOK, odataList{
+		ODataContext: fmt.Sprintf("http://fake.analysis.windows.net/v1.0/myorg/groups/%s/$metadata#%s", id, kind),
+		Value:        value,
+	})
+}
+
+// groupContents returns the contents of a group, creating them on first use.
+func (s *Server) groupContents(groupId string) *groupContents {
+	contents, ok := s.contents[groupId]
+	if !ok {
+		contents = &groupContents{}
+		s.contents[groupId] = contents
+	}
+	return contents
+}
+
+// isContentKind reports whether a path segment names a kind of group content.
+func isContentKind(segment string) bool {
+	switch segment {
+	case "reports", "datasets", "dashboards", "dataflows":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DataflowStorageAccount is an Azure Data Lake Storage Gen2 account registered in the tenant for dataflows,
+// as stored and returned by the fake server.
+type DataflowStorageAccount struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	IsEnabled bool   `json:"isEnabled"`
+}
+
+// assignToDataflowStorageRequest is the body accepted by the assign to dataflow storage endpoint.
+type assignToDataflowStorageRequest struct {
+	DataflowStorageId string `json:"dataflowStorageId"`
+}
+
+// PutDataflowStorageAccount stores a dataflow storage account, replacing any account with the same ID,
+// and returns the stored copy. An ID is generated when none is set.
+func (s *Server) PutDataflowStorageAccount(a DataflowStorageAccount) DataflowStorageAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.Id == "" {
+		a.Id = newId()
+	}
+
+	for i := range s.dataflowStorageAccounts {
+		if strings.EqualFold(s.dataflowStorageAccounts[i].Id, a.Id) {
+			s.dataflowStorageAccounts[i] = a
+			return a
+		}
+	}
+
+	s.dataflowStorageAccounts = append(s.dataflowStorageAccounts, a)
+	return a
+}
+
+// listDataflowStorageAccounts implements GET /dataflowStorageAccounts.
+func (s *Server) listDataflowStorageAccounts(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, odataList{
+		ODataContext: "http://fake.analysis.windows.net/v1.0/myorg/$metadata#dataflowStorageAccounts",
+		Value:        append([]DataflowStorageAccount{}, s.dataflowStorageAccounts...),
+	})
+}
+
+// assignToDataflowStorage implements POST /groups/{groupId}/AssignToDataflowStorage.
+// Like the service, it refuses the unknown accounts and the accounts workspaces cannot be assigned to.
+func (s *Server) assignToDataflowStorage(w http.ResponseWriter, id string, body []byte) {
+	g := s.findGroup(id)
+	if g == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	var req assignToDataflowStorageRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if req.DataflowStorageId == emptyId {
+		g.DataflowStorageId = ""
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	account := s.findDataflowStorageAccount(req.DataflowStorageId)
+	if account == nil {
+		writeNotFound(w, fmt.Sprintf("Dataflow storage account %s", req.DataflowStorageId))
+		return
+	}
+	if !account.IsEnabled {
+		writeError(w, http.StatusBadRequest, "DataflowStorageAccountDisabled",
+			fmt.Sprintf("Workspaces cannot be assigned to the dataflow storage account %s", account.Id))
+		return
+	}
+
+	g.DataflowStorageId = account.Id
+	w.WriteHeader(http.StatusOK)
+}
+
+// findDataflowStorageAccount returns the dataflow storage account with the given ID, or nil.
+func (s *Server) findDataflowStorageAccount(id string) *DataflowStorageAccount {
+	for i := range s.dataflowStorageAccounts {
+		if strings.EqualFold(s.dataflowStorageAccounts[i].Id, id) {
+			return &s.dataflowStorageAccounts[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package fake
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// filterClause matches one OData clause supported by the fake server, such as "name eq 'x'" or "contains(name,'x')".
+// String literals escape single quotes by doubling them, as in OData.
+var filterClause = regexp.MustCompile(`^\s*(?:(\w+)\s+eq\s+'((?:[^']|'')*)'|(contains|startswith|endswith)\(\s*(\w+)\s*,\s*'((?:[^']|'')*)'\s*\))\s*`)
+
+// filterAnd matches the conjunction between two clauses.
+var filterAnd = regexp.MustCompile(`^(?i)and\s+`)
+
+// parseFilter turns the subset of OData $filter expressions used against the groups endpoints into a predicate.
+// Only string comparisons on the given properties, joined with "and", are supported.
+func parseFilter[T any](filter string, properties map[string]func(T) string) (func(T) bool, error) {
+	predicates := []func(T) bool{}
+	rest := filter
+
+	for strings.TrimSpace(rest) != "" {
+		m := filterClause.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("unsupported $filter expression %q", filter)
+		}
+		rest = rest[len(m[0]):]
+
+		property, operator, value := m[1], "eq", m[2]
+		if property == "" {
+			property, operator, value = m[4], m[3], m[5]
+		}
+		value = strings.ReplaceAll(value, "''", "'")
+
+		get, ok := properties[property]
+		if !ok {
+			return nil, fmt.Errorf("unsupported $filter property %q", property)
+		}
+
+		predicates = append(predicates, stringPredicate(get, operator, value))
+
+		if strings.TrimSpace(rest) != "" {
+			and := filterAnd.FindString(rest)
+			if and == "" {
+				return nil, fmt.Errorf("unsupported $filter expression %q", filter)
+			}
+			rest = rest[len(and):]
+		}
+	}
+
+	return func(v T) bool {
+		for _, p := range predicates {
+			if !p(v) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// stringPredicate compares a string property with value using an OData string operator.
+// The service compares workspace names and states case-insensitively, so does the fake.
+func stringPredicate[T any](get func(T) string, operator string, value string) func(T) bool {
+	value = strings.ToLower(value)
+
+	return func(v T) bool {
+		property := strings.ToLower(get(v))
+		switch operator {
+		case "contains":
+			return strings.Contains(property, value)
+		case "startswith":
+			return strings.HasPrefix(property, value)
+		case "endswith":
+			return strings.HasSuffix(property, value)
+		default:
+			return property == value
+		}
+	}
+}
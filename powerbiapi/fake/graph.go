@@ -0,0 +1,149 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GraphUser is a Microsoft Entra user served by the Microsoft Graph endpoints of the fake server.
+type GraphUser struct {
+	Id                string `json:"id"`
+	DisplayName       string `json:"displayName,omitempty"`
+	Mail              string `json:"mail,omitempty"`
+	UserPrincipalName string `json:"userPrincipalName"`
+}
+
+// GraphGroup is a Microsoft Entra group served by the Microsoft Graph endpoints of the fake server.
+type GraphGroup struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Mail        string `json:"mail,omitempty"`
+}
+
+// GraphServicePrincipal is a Microsoft Entra service principal served by the Microsoft Graph endpoints of the fake server.
+type GraphServicePrincipal struct {
+	Id          string `json:"id"`
+	AppId       string `json:"appId"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// PutGraphUser stores a directory user and returns the stored copy. An ID is generated when none is set.
+func (s *Server) PutGraphUser(u GraphUser) GraphUser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u.Id == "" {
+		u.Id = newId()
+	}
+
+	s.graphUsers = append(s.graphUsers, u)
+	return u
+}
+
+// PutGraphGroup stores a directory group and returns the stored copy. An ID is generated when none is set.
+// Several groups may share a display name, as in Microsoft Entra.
+func (s *Server) PutGraphGroup(g GraphGroup) GraphGroup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g.Id == "" {
+		g.Id = newId()
+	}
+
+	s.graphGroups = append(s.graphGroups, g)
+	return g
+}
+
+// PutGraphServicePrincipal stores a directory service principal and returns the stored copy.
+// The object and application IDs are generated when none are set.
+func (s *Server) PutGraphServicePrincipal(sp GraphServicePrincipal) GraphServicePrincipal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sp.Id == "" {
+		sp.Id = newId()
+	}
+	if sp.AppId == "" {
+		sp.AppId = newId()
+	}
+
+	s.graphServicePrincipals = append(s.graphServicePrincipals, sp)
+	return sp
+}
+
+// routeGraph dispatches the /v1.0 endpoints of Microsoft Graph.
+func (s *Server) routeGraph(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 1 && segments[0] == "groups" && r.Method == http.MethodGet:
+		s.listGraphGroups(w, r)
+	case len(segments) == 1 && segments[0] == "servicePrincipals" && r.Method == http.MethodGet:
+		s.listGraphServicePrincipals(w, r)
+	case len(segments) == 2 && segments[0] == "users" && r.Method == http.MethodGet:
+		s.getGraphUser(w, segments[1])
+	default:
+		writeGraphNotFound(w, r.URL.Path)
+	}
+}
+
+// getGraphUser implements GET /v1.0/users/{userId}, the user being given by object ID or user principal name.
+func (s *Server) getGraphUser(w http.ResponseWriter, userId string) {
+	for _, u := range s.graphUsers {
+		if strings.EqualFold(u.Id, userId) || strings.EqualFold(u.UserPrincipalName, userId) {
+			writeJSON(w, http.StatusOK, u)
+			return
+		}
+	}
+
+	writeGraphNotFound(w, fmt.Sprintf("User %s", userId))
+}
+
+// listGraphGroups implements GET /v1.0/groups.
+func (s *Server) listGraphGroups(w http.ResponseWriter, r *http.Request) {
+	match, err := parseFilter(r.URL.Query().Get("$filter"), map[string]func(GraphGroup) string{
+		"displayName": func(g GraphGroup) string { return g.DisplayName },
+		"mail":        func(g GraphGroup) string { return g.Mail },
+		"id":          func(g GraphGroup) string { return g.Id },
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Request_UnsupportedQuery", err.Error())
+		return
+	}
+
+	groups := []GraphGroup{}
+	for _, g := range s.graphGroups {
+		if match(g) {
+			groups = append(groups, g)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, odataList{ODataContext: "https://graph.microsoft.com/v1.0/$metadata#groups", Value: groups})
+}
+
+// listGraphServicePrincipals implements GET /v1.0/servicePrincipals.
+func (s *Server) listGraphServicePrincipals(w http.ResponseWriter, r *http.Request) {
+	match, err := parseFilter(r.URL.Query().Get("$filter"), map[string]func(GraphServicePrincipal) string{
+		"appId":       func(sp GraphServicePrincipal) string { return sp.AppId },
+		"displayName": func(sp GraphServicePrincipal) string { return sp.DisplayName },
+		"id":          func(sp GraphServicePrincipal) string { return sp.Id },
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Request_UnsupportedQuery", err.Error())
+		return
+	}
+
+	servicePrincipals := []GraphServicePrincipal{}
+	for _, sp := range s.graphServicePrincipals {
+		if match(sp) {
+			servicePrincipals = append(servicePrincipals, sp)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, odataList{ODataContext: "https://graph.microsoft.com/v1.0/$metadata#servicePrincipals", Value: servicePrincipals})
+}
+
+// writeGraphNotFound writes the error returned by Microsoft Graph for unknown directory objects.
+// Microsoft Graph wraps its errors like the Power BI API, with its own codes.
+func writeGraphNotFound(w http.ResponseWriter, what string) {
+	writeError(w, http.StatusNotFound, "Request_ResourceNotFound", fmt.Sprintf("%s does not exist", what))
+}
@@ -0,0 +1,515 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CallerId is the object ID of the principal the fake server authenticates every request as.
+// Like the Power BI service, the fake grants it the Admin role on every workspace it creates.
+const CallerId string = "8f2d1c6e-5b4a-4f3e-9d2c-1a0b9c8d7e6f"
+
+// Group is a Power BI group (workspace) as stored and returned by the fake server.
+type Group struct {
+	Id                          string         `json:"id"`
+	Name                        string         `json:"name"`
+	IsReadOnly                  bool           `json:"isReadOnly"`
+	IsOnDedicatedCapacity       bool           `json:"isOnDedicatedCapacity"`
+	CapacityId                  string         `json:"capacityId,omitempty"`
+	DataflowStorageId           string         `json:"dataflowStorageId,omitempty"`
+	DefaultDatasetStorageFormat string         `json:"defaultDatasetStorageFormat,omitempty"`
+	LogAnalyticsWorkspace       *AzureResource `json:"logAnalyticsWorkspace,omitempty"`
+	Type                        string         `json:"type"`
+	Description                 string         `json:"-"` // Only exposed by the Fabric workspaces API.
+}
+
+// AzureResource is a user-owned Azure resource, such as the Log Analytics workspace assigned to a group.
+type AzureResource struct {
+	Id             string `json:"id"`
+	SubscriptionId string `json:"subscriptionId"`
+	ResourceGroup  string `json:"resourceGroup"`
+	ResourceName   string `json:"resourceName"`
+}
+
+// GroupUser is a principal with access to a group, as stored and returned by the fake server.
+type GroupUser struct {
+	DisplayName          string   `json:"displayName,omitempty"`
+	EmailAddress         string   `json:"emailAddress,omitempty"`
+	GroupUserAccessRight string   `json:"groupUserAccessRight"`
+	Identifier           string   `json:"identifier"`
+	PrincipalType        string   `json:"principalType"`
+	UserType             string   `json:"userType,omitempty"`
+	GraphId              string   `json:"-"` // Only returned by the admin API, the identifier of groups and apps when not set.
+	Profile              *Profile `json:"profile,omitempty"`
+}
+
+// Profile is a service principal profile, scoping the access of an app in Power BI Embedded multi-tenancy solutions.
+type Profile struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// groupCreationRequest is the body accepted by the create group endpoint.
+type groupCreationRequest struct {
+	Name string `json:"name"`
+}
+
+// groupUpdateRequest is the body accepted by the update group endpoint.
+type groupUpdateRequest struct {
+	Name                        *string `json:"name"`
+	DefaultDatasetStorageFormat *string `json:"defaultDatasetStorageFormat"`
+}
+
+// groupUserRequest is the body accepted by the add and update group user endpoints.
+type groupUserRequest struct {
+	EmailAddress         string   `json:"emailAddress"`
+	Identifier           string   `json:"identifier"`
+	GroupUserAccessRight string   `json:"groupUserAccessRight"`
+	PrincipalType        string   `json:"principalType"`
+	Profile              *Profile `json:"profile"`
+}
+
+// PutGroup stores a group, replacing any group with the same ID, and returns the stored copy.
+// An ID is generated when none is set. It is meant to seed the server or to simulate out-of-band changes.
+func (s *Server) PutGroup(g Group) Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g.Id == "" {
+		g.Id = newId()
+	}
+	if g.Type == "" {
+		g.Type = "Workspace"
+	}
+
+	if existing := s.findGroup(g.Id); existing != nil {
+		*existing = g
+		return g
+	}
+
+	s.groups = append(s.groups, &g)
+	return g
+}
+
+// Group returns a copy of the group with the given ID.
+func (s *Server) Group(id string) (Group, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := s.findGroup(id)
+	if g == nil {
+		return Group{}, false
+	}
+	return *g, true
+}
+
+// Groups returns a copy of every group, in creation order.
+func (s *Server) Groups() []Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := make([]Group, 0, len(s.groups))
+	for _, g := range s.groups {
+		groups = append(groups, *g)
+	}
+	return groups
+}
+
+// RemoveGroup deletes a group behind the client's back, ignoring pipeline assignments.
+// It returns false when the group does not exist.
+func (s *Server) RemoveGroup(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.pipelines {
+		for i := range p.Stages {
+			if p.Stages[i].WorkspaceId == id {
+				p.Stages[i].WorkspaceId = ""
+			}
+		}
+	}
+
+	return s.removeGroup(id)
+}
+
+// PutGroupUser grants a principal access to a group, replacing its previous access right.
+func (s *Server) PutGroupUser(groupId string, user GroupUser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.putUser(groupId, user)
+}
+
+// RemoveGroupUser revokes the access of a principal to a group behind the client's back.
+// It returns false when the principal has no access to the group.
+func (s *Server) RemoveGroupUser(groupId string, identifier string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := s.users[groupId]
+	for i := range users {
+		if strings.EqualFold(users[i].Identifier, identifier) {
+			s.users[groupId] = append(users[:i:i], users[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GroupUsers returns a copy of the principals with access to a group.
+func (s *Server) GroupUsers(groupId string) []GroupUser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]GroupUser(nil), s.users[groupId]...)
+}
+
+// routeGroups dispatches the /v1.0/myorg/groups endpoints.
+func (s *Server) routeGroups(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listGroups(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createGroup(w, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getGroup(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		s.updateGroup(w, segments[0], body)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.deleteGroup(w, segments[0])
+	case len(segments) == 2 && segments[1] == "AssignToCapacity" && r.Method == http.MethodPost:
+		s.assignToCapacity(w, segments[0], body)
+	case len(segments) == 2 && segments[1] == "AssignToDataflowStorage" && r.Method == http.MethodPost:
+		s.assignToDataflowStorage(w, segments[0], body)
+	case len(segments) == 2 && segments[1] == "CapacityAssignmentStatus" && r.Method == http.MethodGet:
+		s.getCapacityAssignmentStatus(w, segments[0])
+	case len(segments) == 2 && isContentKind(segments[1]) && r.Method == http.MethodGet:
+		s.listGroupContents(w, segments[0], segments[1])
+	case len(segments) == 2 && segments[1] == "users" && r.Method == http.MethodGet:
+		s.listGroupUsers(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "users" && r.Method == http.MethodPost:
+		s.addGroupUser(w, segments[0], body)
+	case len(segments) == 2 && segments[1] == "users" && r.Method == http.MethodPut:
+		s.updateGroupUser(w, segments[0], body)
+	case len(segments) == 3 && segments[1] == "users" && r.Method == http.MethodDelete:
+		s.deleteGroupUser(w, segments[0], segments[2])
+	default:
+		writeNotFound(w, r.URL.Path)
+	}
+}
+
+// listGroups implements GET /groups with the $filter, $top and $skip query parameters.
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	match, err := parseFilter(query.Get("$filter"), map[string]func(*Group) string{
+		"name": func(g *Group) string { return g.Name },
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return
+	}
+
+	groups := []Group{}
+	for _, g := range s.groups {
+		if match(g) {
+			groups = append(groups, *g)
+		}
+	}
+
+	start, end, ok := pageBounds(w, query, len(groups))
+	if !ok {
+		return
+	}
+	groups = groups[start:end]
+
+	writeJSON(w, http.StatusOK, odataList{
+		ODataContext: "http://fake.analysis.windows.net/v1.0/myorg/$metadata#groups",
+		ODataCount:   len(groups),
+		Value:        groups,
+	})
+}
+
+// createGroup implements POST /groups.
+func (s *Server) createGroup(w http.ResponseWriter, body []byte) {
+	var req groupCreationRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", "The workspace name is required")
+		return
+	}
+
+	if s.findGroupByName(req.Name) != nil {
+		writeError(w, http.StatusConflict, "PowerBIEntityAlreadyExists", fmt.Sprintf("A workspace named %s already exists", req.Name))
+		return
+	}
+
+	g := &Group{Id: newId(), Name: req.Name, Type: "Workspace"}
+	s.groups = append(s.groups, g)
+	s.putUser(g.Id, GroupUser{
+		DisplayName:          "fake caller",
+		GroupUserAccessRight: "Admin",
+		Identifier:           CallerId,
+		PrincipalType:        "App",
+	})
+
+	writeJSON(w, http.StatusOK, g)
+}
+
+// getGroup implements GET /groups/{groupId}.
+func (s *Server) getGroup(w http.ResponseWriter, id string) {
+	g := s.findGroup(id)
+	if g == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, g)
+}
+
+// updateGroup implements PATCH /groups/{groupId}.
+func (s *Server) updateGroup(w http.ResponseWriter, id string, body []byte) {
+	g := s.findGroup(id)
+	if g == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	var req groupUpdateRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", "The workspace name cannot be empty")
+			return
+		}
+		if other := s.findGroupByName(*req.Name); other != nil && other.Id != id {
+			writeError(w, http.StatusConflict, "PowerBIEntityAlreadyExists", fmt.Sprintf("A workspace named %s already exists", *req.Name))
+			return
+		}
+	}
+
+	if req.DefaultDatasetStorageFormat != nil {
+		if !g.IsOnDedicatedCapacity {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", "The default dataset storage format requires a dedicated capacity")
+			return
+		}
+		if *req.DefaultDatasetStorageFormat != "Small" && *req.DefaultDatasetStorageFormat != "Large" {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("Invalid default dataset storage format %s", *req.DefaultDatasetStorageFormat))
+			return
+		}
+	}
+
+	if req.DefaultDatasetStorageFormat != nil {
+		g.DefaultDatasetStorageFormat = *req.DefaultDatasetStorageFormat
+	}
+	if req.Name != nil {
+		g.Name = *req.Name
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// deleteGroup implements DELETE /groups/{groupId}.
+// Like the service, it refuses to delete a workspace that is still assigned to a deployment pipeline stage.
+func (s *Server) deleteGroup(w http.ResponseWriter, id string) {
+	if s.findGroup(id) == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	if p, stage := s.findStageByWorkspace(id); p != nil {
+		writeError(w, http.StatusBadRequest, "WorkspaceAssignedToPipeline",
+			fmt.Sprintf("Workspace %s is assigned to stage %d of pipeline %s", id, stage.Order, p.Id))
+		return
+	}
+
+	// The service keeps deleted workspaces for a retention period, during which an administrator can restore them.
+	// The fake keeps the group only: its users and contents are dropped.
+	deleted := *s.findGroup(id)
+	s.removeGroup(id)
+	s.deletedGroups = append(s.deletedGroups, &deleted)
+	w.WriteHeader(http.StatusOK)
+}
+
+// listGroupUsers implements GET /groups/{groupId}/users with the $top and $skip query parameters.
+func (s *Server) listGroupUsers(w http.ResponseWriter, r *http.Request, id string) {
+	if s.findGroup(id) == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	start, end, ok := pageBounds(w, r.URL.Query(), len(s.users[id]))
+	if !ok {
+		return
+	}
+
+	users := append([]GroupUser{}, s.users[id][start:end]...)
+	writeJSON(w, http.StatusOK, odataList{
+		ODataContext: fmt.Sprintf("http://fake.analysis.windows.net/v1.0/myorg/groups/%s/$metadata#users", id),
+		Value:        users,
+	})
+}
+
+// addGroupUser implements POST /groups/{groupId}/users.
+func (s *Server) addGroupUser(w http.ResponseWriter, id string, body []byte) {
+	if s.findGroup(id) == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	user, ok := decodeGroupUser(w, body)
+	if !ok {
+		return
+	}
+
+	if s.findUser(id, user.Identifier) != nil {
+		writeError(w, http.StatusBadRequest, "AddingAlreadyExistsGroupUserNotSupportedError",
+			fmt.Sprintf("%s already has access to workspace %s", user.Identifier, id))
+		return
+	}
+
+	s.putUser(id, user)
+	w.WriteHeader(http.StatusOK)
+}
+
+// updateGroupUser implements PUT /groups/{groupId}/users.
+func (s *Server) updateGroupUser(w http.ResponseWriter, id string, body []byte) {
+	if s.findGroup(id) == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	user, ok := decodeGroupUser(w, body)
+	if !ok {
+		return
+	}
+
+	if s.findUser(id, user.Identifier) == nil {
+		writeNotFound(w, fmt.Sprintf("User %s in workspace %s", user.Identifier, id))
+		return
+	}
+
+	s.putUser(id, user)
+	w.WriteHeader(http.StatusOK)
+}
+
+// deleteGroupUser implements DELETE /groups/{groupId}/users/{user}.
+func (s *Server) deleteGroupUser(w http.ResponseWriter, id string, identifier string) {
+	if s.findGroup(id) == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", id))
+		return
+	}
+
+	users := s.users[id]
+	for i := range users {
+		if strings.EqualFold(users[i].Identifier, identifier) {
+			s.users[id] = append(users[:i:i], users[i+1:]...)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	writeNotFound(w, fmt.Sprintf("User %s in workspace %s", identifier, id))
+}
+
+// decodeGroupUser decodes and checks a group user request, writing a 400 response when it is invalid.
+func decodeGroupUser(w http.ResponseWriter, body []byte) (GroupUser, bool) {
+	var req groupUserRequest
+	if !decodeBody(w, body, &req) {
+		return GroupUser{}, false
+	}
+
+	switch req.GroupUserAccessRight {
+	case "Admin", "Contributor", "Member", "Viewer":
+	default:
+		writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("Invalid access right %q", req.GroupUserAccessRight))
+		return GroupUser{}, false
+	}
+
+	user := GroupUser{GroupUserAccessRight: req.GroupUserAccessRight, PrincipalType: req.PrincipalType}
+	switch {
+	case req.PrincipalType == "User" && req.EmailAddress != "":
+		user.EmailAddress = req.EmailAddress
+		user.Identifier = req.EmailAddress
+		user.DisplayName = req.EmailAddress
+	case req.PrincipalType == "User" && req.Identifier != "":
+		user.Identifier = req.Identifier
+	case (req.PrincipalType == "Group" || req.PrincipalType == "App") && req.Identifier != "":
+		user.Identifier = req.Identifier
+	default:
+		writeError(w, http.StatusBadRequest, "InvalidRequest",
+			fmt.Sprintf("Invalid principal: type %q, email %q, identifier %q", req.PrincipalType, req.EmailAddress, req.Identifier))
+		return GroupUser{}, false
+	}
+
+	if req.Profile != nil {
+		if req.PrincipalType != "App" || req.Profile.Id == "" {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("Invalid profile %q for principal type %q", req.Profile.Id, req.PrincipalType))
+			return GroupUser{}, false
+		}
+		user.Profile = &Profile{Id: req.Profile.Id}
+	}
+
+	return user, true
+}
+
+// findGroup returns the group with the given ID, or nil.
+func (s *Server) findGroup(id string) *Group {
+	for _, g := range s.groups {
+		if strings.EqualFold(g.Id, id) {
+			return g
+		}
+	}
+	return nil
+}
+
+// findGroupByName returns the group with the given name, compared case-insensitively like the service does, or nil.
+func (s *Server) findGroupByName(name string) *Group {
+	for _, g := range s.groups {
+		if strings.EqualFold(g.Name, name) {
+			return g
+		}
+	}
+	return nil
+}
+
+// removeGroup removes a group and its users, returning false when it does not exist.
+func (s *Server) removeGroup(id string) bool {
+	for i, g := range s.groups {
+		if strings.EqualFold(g.Id, id) {
+			s.groups = append(s.groups[:i:i], s.groups[i+1:]...)
+			delete(s.users, g.Id)
+			delete(s.assignments, g.Id)
+			delete(s.contents, g.Id)
+			return true
+		}
+	}
+	return false
+}
+
+// findUser returns the group user with the given identifier, or nil.
+func (s *Server) findUser(groupId string, identifier string) *GroupUser {
+	users := s.users[groupId]
+	for i := range users {
+		if strings.EqualFold(users[i].Identifier, identifier) {
+			return &users[i]
+		}
+	}
+	return nil
+}
+
+// putUser adds a group user or replaces the access right of an existing one.
+func (s *Server) putUser(groupId string, user GroupUser) {
+	if existing := s.findUser(groupId, user.Identifier); existing != nil {
+		existing.GroupUserAccessRight = user.GroupUserAccessRight
+		return
+	}
+	s.users[groupId] = append(s.users[groupId], user)
+}
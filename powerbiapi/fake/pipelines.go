@@ -0,0 +1,364 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// stageCount is the number of stages of a deployment pipeline (development, test, production).
+const stageCount = 3
+
+// Pipeline is a Power BI deployment pipeline as stored and returned by the fake server.
+type Pipeline struct {
+	Id          string          `json:"id"`
+	DisplayName string          `json:"displayName"`
+	Description string          `json:"description,omitempty"`
+	Stages      []PipelineStage `json:"stages,omitempty"`
+}
+
+// PipelineStage is a deployment pipeline stage as stored and returned by the fake server.
+// The workspace name is resolved when the stage is returned.
+type PipelineStage struct {
+	Order         int    `json:"order"`
+	WorkspaceId   string `json:"workspaceId,omitempty"`
+	WorkspaceName string `json:"workspaceName,omitempty"`
+}
+
+// pipelineRequest is the body accepted by the create and update pipeline endpoints.
+type pipelineRequest struct {
+	DisplayName *string `json:"displayName"`
+	Description *string `json:"description"`
+}
+
+// assignWorkspaceRequest is the body accepted by the assign workspace endpoint.
+type assignWorkspaceRequest struct {
+	WorkspaceId string `json:"workspaceId"`
+}
+
+// PutPipeline stores a pipeline, replacing any pipeline with the same ID, and returns the stored copy.
+// An ID and the missing stages are generated. It is meant to seed the server or to simulate out-of-band changes.
+func (s *Server) PutPipeline(p Pipeline) Pipeline {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p.Id == "" {
+		p.Id = newId()
+	}
+	p.Stages = completeStages(p.Stages)
+
+	if existing := s.findPipeline(p.Id); existing != nil {
+		*existing = p
+		return s.expandPipeline(&p, true)
+	}
+
+	s.pipelines = append(s.pipelines, &p)
+	return s.expandPipeline(&p, true)
+}
+
+// Pipeline returns a copy of the pipeline with the given ID, stages included.
+func (s *Server) Pipeline(id string) (Pipeline, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findPipeline(id)
+	if p == nil {
+		return Pipeline{}, false
+	}
+	return s.expandPipeline(p, true), true
+}
+
+// Pipelines returns a copy of every pipeline, in creation order and with their stages.
+func (s *Server) Pipelines() []Pipeline {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipelines := make([]Pipeline, 0, len(s.pipelines))
+	for _, p := range s.pipelines {
+		pipelines = append(pipelines, s.expandPipeline(p, true))
+	}
+	return pipelines
+}
+
+// RemovePipeline deletes a pipeline behind the client's back.
+// It returns false when the pipeline does not exist.
+func (s *Server) RemovePipeline(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.removePipeline(id)
+}
+
+// routePipelines dispatches the /v1.0/myorg/pipelines endpoints.
+func (s *Server) routePipelines(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	expand := r.URL.Query().Get("$expand") == "stages"
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listPipelines(w, expand)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createPipeline(w, body)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getPipeline(w, segments[0], expand)
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		s.updatePipeline(w, segments[0], body)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.deletePipeline(w, segments[0])
+	case len(segments) == 2 && segments[1] == "stages" && r.Method == http.MethodGet:
+		s.listStages(w, segments[0])
+	case len(segments) == 4 && segments[1] == "stages" && segments[3] == "assignWorkspace" && r.Method == http.MethodPost:
+		s.assignWorkspace(w, segments[0], segments[2], body)
+	case len(segments) == 4 && segments[1] == "stages" && segments[3] == "unassignWorkspace" && r.Method == http.MethodPost:
+		s.unassignWorkspace(w, segments[0], segments[2])
+	default:
+		writeNotFound(w, r.URL.Path)
+	}
+}
+
+// listPipelines implements GET /pipelines.
+func (s *Server) listPipelines(w http.ResponseWriter, expand bool) {
+	pipelines := []Pipeline{}
+	for _, p := range s.pipelines {
+		pipelines = append(pipelines, s.expandPipeline(p, expand))
+	}
+
+	writeJSON(w, http.StatusOK, odataList{
+		ODataContext: "http://fake.analysis.windows.net/v1.0/myorg/$metadata#pipelines",
+		Value:        pipelines,
+	})
+}
+
+// createPipeline implements POST /pipelines.
+func (s *Server) createPipeline(w http.ResponseWriter, body []byte) {
+	var req pipelineRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if req.DisplayName == nil || strings.TrimSpace(*req.DisplayName) == "" {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", "The pipeline display name is required")
+		return
+	}
+
+	if s.findPipelineByName(*req.DisplayName) != nil {
+		writeError(w, http.StatusConflict, "PowerBIEntityAlreadyExists", fmt.Sprintf("A pipeline named %s already exists", *req.DisplayName))
+		return
+	}
+
+	p := &Pipeline{Id: newId(), DisplayName: *req.DisplayName, Stages: completeStages(nil)}
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+	s.pipelines = append(s.pipelines, p)
+
+	writeJSON(w, http.StatusOK, s.expandPipeline(p, false))
+}
+
+// getPipeline implements GET /pipelines/{pipelineId}.
+func (s *Server) getPipeline(w http.ResponseWriter, id string, expand bool) {
+	p := s.findPipeline(id)
+	if p == nil {
+		writeNotFound(w, fmt.Sprintf("Pipeline %s", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.expandPipeline(p, expand))
+}
+
+// updatePipeline implements PATCH /pipelines/{pipelineId}.
+func (s *Server) updatePipeline(w http.ResponseWriter, id string, body []byte) {
+	p := s.findPipeline(id)
+	if p == nil {
+		writeNotFound(w, fmt.Sprintf("Pipeline %s", id))
+		return
+	}
+
+	var req pipelineRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if req.DisplayName != nil {
+		if strings.TrimSpace(*req.DisplayName) == "" {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", "The pipeline display name cannot be empty")
+			return
+		}
+		if other := s.findPipelineByName(*req.DisplayName); other != nil && other.Id != p.Id {
+			writeError(w, http.StatusConflict, "PowerBIEntityAlreadyExists", fmt.Sprintf("A pipeline named %s already exists", *req.DisplayName))
+			return
+		}
+		p.DisplayName = *req.DisplayName
+	}
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+
+	writeJSON(w, http.StatusOK, s.expandPipeline(p, false))
+}
+
+// deletePipeline implements DELETE /pipelines/{pipelineId}.
+func (s *Server) deletePipeline(w http.ResponseWriter, id string) {
+	if !s.removePipeline(id) {
+		writeNotFound(w, fmt.Sprintf("Pipeline %s", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// listStages implements GET /pipelines/{pipelineId}/stages.
+func (s *Server) listStages(w http.ResponseWriter, id string) {
+	p := s.findPipeline(id)
+	if p == nil {
+		writeNotFound(w, fmt.Sprintf("Pipeline %s", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, odataList{
+		ODataContext: fmt.Sprintf("http://fake.analysis.windows.net/v1.0/myorg/$metadata#pipelines('%s')/stages", id),
+		Value:        s.expandPipeline(p, true).Stages,
+	})
+}
+
+// assignWorkspace implements POST /pipelines/{pipelineId}/stages/{stageOrder}/assignWorkspace.
+func (s *Server) assignWorkspace(w http.ResponseWriter, id string, order string, body []byte) {
+	p, stage := s.findStage(w, id, order)
+	if stage == nil {
+		return
+	}
+
+	var req assignWorkspaceRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if s.findGroup(req.WorkspaceId) == nil {
+		writeNotFound(w, fmt.Sprintf("Workspace %s", req.WorkspaceId))
+		return
+	}
+
+	if stage.WorkspaceId != "" {
+		writeError(w, http.StatusBadRequest, "PipelineStageAlreadyAssigned", fmt.Sprintf("Stage %d of pipeline %s already has a workspace", stage.Order, p.Id))
+		return
+	}
+
+	if other, _ := s.findStageByWorkspace(req.WorkspaceId); other != nil {
+		writeError(w, http.StatusBadRequest, "WorkspaceAssignedToPipeline", fmt.Sprintf("Workspace %s is already assigned to pipeline %s", req.WorkspaceId, other.Id))
+		return
+	}
+
+	stage.WorkspaceId = req.WorkspaceId
+	w.WriteHeader(http.StatusOK)
+}
+
+// unassignWorkspace implements POST /pipelines/{pipelineId}/stages/{stageOrder}/unassignWorkspace.
+func (s *Server) unassignWorkspace(w http.ResponseWriter, id string, order string) {
+	p, stage := s.findStage(w, id, order)
+	if stage == nil {
+		return
+	}
+
+	if stage.WorkspaceId == "" {
+		writeError(w, http.StatusBadRequest, "PipelineStageNotAssigned", fmt.Sprintf("Stage %d of pipeline %s has no workspace", stage.Order, p.Id))
+		return
+	}
+
+	stage.WorkspaceId = ""
+	w.WriteHeader(http.StatusOK)
+}
+
+// findStage resolves a pipeline stage from the URL, writing a 404 response when it does not exist.
+func (s *Server) findStage(w http.ResponseWriter, id string, order string) (*Pipeline, *PipelineStage) {
+	p := s.findPipeline(id)
+	if p == nil {
+		writeNotFound(w, fmt.Sprintf("Pipeline %s", id))
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(order)
+	if err != nil || n < 0 || n >= len(p.Stages) {
+		writeNotFound(w, fmt.Sprintf("Stage %s of pipeline %s", order, id))
+		return nil, nil
+	}
+
+	return p, &p.Stages[n]
+}
+
+// findPipeline returns the pipeline with the given ID, or nil.
+func (s *Server) findPipeline(id string) *Pipeline {
+	for _, p := range s.pipelines {
+		if strings.EqualFold(p.Id, id) {
+			return p
+		}
+	}
+	return nil
+}
+
+// findPipelineByName returns the pipeline with the given display name, or nil.
+func (s *Server) findPipelineByName(name string) *Pipeline {
+	for _, p := range s.pipelines {
+		if strings.EqualFold(p.DisplayName, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// findStageByWorkspace returns the pipeline and stage a workspace is assigned to, or nil.
+func (s *Server) findStageByWorkspace(workspaceId string) (*Pipeline, *PipelineStage) {
+	for _, p := range s.pipelines {
+		for i := range p.Stages {
+			if p.Stages[i].WorkspaceId != "" && strings.EqualFold(p.Stages[i].WorkspaceId, workspaceId) {
+				return p, &p.Stages[i]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// removePipeline removes a pipeline, releasing its workspaces. It returns false when it does not exist.
+func (s *Server) removePipeline(id string) bool {
+	for i, p := range s.pipelines {
+		if strings.EqualFold(p.Id, id) {
+			s.pipelines = append(s.pipelines[:i:i], s.pipelines[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// expandPipeline returns a copy of the pipeline as the API returns it.
+// Stages are only included when expanded, with the names of their workspaces resolved.
+func (s *Server) expandPipeline(p *Pipeline, expand bool) Pipeline {
+	out := *p
+	out.Stages = nil
+	if !expand {
+		return out
+	}
+
+	for _, stage := range p.Stages {
+		if g := s.findGroup(stage.WorkspaceId); g != nil {
+			stage.WorkspaceName = g.Name
+		} else {
+			stage.WorkspaceId = ""
+			stage.WorkspaceName = ""
+		}
+		out.Stages = append(out.Stages, stage)
+	}
+	return out
+}
+
+// completeStages returns the stages of a pipeline, adding the missing ones so it always has stageCount stages.
+func completeStages(stages []PipelineStage) []PipelineStage {
+	complete := make([]PipelineStage, stageCount)
+	for i := range complete {
+		complete[i].Order = i
+	}
+	for _, stage := range stages {
+		if stage.Order >= 0 && stage.Order < stageCount {
+			complete[stage.Order].WorkspaceId = stage.WorkspaceId
+		}
+	}
+	return complete
+}
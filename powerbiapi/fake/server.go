@@ -0,0 +1,315 @@
+// Package fake provides a stateful, in-process emulation of the Power BI REST API.
+// It also serves the workspace endpoints of the Fabric REST API, under /v1, on the same groups, and the
+// Microsoft Graph lookups of users, groups and service principals, under /v1.0 next to /v1.0/myorg.
+//
+// The server keeps groups (workspaces), the deleted groups the admin API restores, group users, the reports,
+// datasets, dashboards and dataflows of the groups, capacities, dataflow storage accounts, deployment pipelines
+// and their stages in memory, so client and provider tests can exercise create, read, update and delete flows
+// without a tenant.
+// It answers with the same status codes and error payloads as the Power BI service for the cases
+// the provider cares about: unknown IDs, duplicate names and throttling.
+//
+// Every request is also checked against the snapshot of the API specification (package openapi).
+// Requests breaking the contract are answered with 400 Bad Request and kept as violations, which
+// NewTestServer reports as test failures, so malformed calls are caught by any test run.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/internal/openapi"
+)
+
+// Token is the only bearer token accepted by the fake server.
+const Token string = "fake-power-bi-token"
+
+// emptyId is the empty GUID, which unassigns a workspace from its capacity or dataflow storage account.
+const emptyId string = "00000000-0000-0000-0000-000000000000"
+
+// Server is an in-memory Power BI REST API served over HTTP.
+type Server struct {
+	*httptest.Server
+
+	mu                      sync.Mutex
+	groups                  []*Group
+	deletedGroups           []*Group
+	users                   map[string][]GroupUser
+	pipelines               []*Pipeline
+	capacities              map[string]Capacity
+	contents                map[string]*groupContents
+	dataflowStorageAccounts []DataflowStorageAccount
+	assignments             map[string]*capacityAssignment
+	assignmentPolls         int
+	itemsPageSize           int
+	fabricForbidden         bool
+	graphUsers              []GraphUser
+	graphGroups             []GraphGroup
+	graphServicePrincipals  []GraphServicePrincipal
+	throttled               int
+	retryAfter              time.Duration
+	requests                []Request
+	spec                    *openapi.Spec
+	fabricSpec              *openapi.Spec
+	graphSpec               *openapi.Spec
+	violations              []error
+}
+
+// Request is a request received by the fake server, kept for assertions.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// apiError is the error payload returned by the Power BI REST API.
+type apiError struct {
+	Error apiErrorDetail `json:"error"`
+}
+
+// apiErrorDetail is the body of an apiError.
+type apiErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// odataList is the OData wrapper used by the list endpoints.
+type odataList struct {
+	ODataContext string      `json:"@odata.context"`
+	ODataCount   int         `json:"@odata.count,omitempty"`
+	Value        interface{} `json:"value"`
+}
+
+// NewServer starts a new fake Power BI server with an empty tenant.
+// The caller must call Close when done.
+func NewServer() *Server {
+	spec, err := openapi.Load()
+	if err != nil {
+		panic(err)
+	}
+	fabricSpec, err := openapi.LoadFabric()
+	if err != nil {
+		panic(err)
+	}
+	graphSpec, err := openapi.LoadGraph()
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{
+		users:       map[string][]GroupUser{},
+		capacities:  map[string]Capacity{},
+		contents:    map[string]*groupContents{},
+		assignments: map[string]*capacityAssignment{},
+		spec:        spec,
+		fabricSpec:  fabricSpec,
+		graphSpec:   graphSpec,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewTestServer starts a new fake Power BI server that is closed at the end of the test.
+// The requests breaking the API specification are then reported as test errors.
+func NewTestServer(t testing.TB) *Server {
+	s := NewServer()
+	t.Cleanup(func() {
+		s.Close()
+		for _, violation := range s.Violations() {
+			t.Errorf("request breaks the Power BI API specification: %v", violation)
+		}
+	})
+	return s
+}
+
+// Throttle makes the next n requests fail with 429 Too Many Requests and the given Retry-After delay.
+func (s *Server) Throttle(n int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.throttled = n
+	s.retryAfter = retryAfter
+}
+
+// Requests returns the requests received so far, throttled ones included.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Violations returns the errors of the requests that did not match the API specification.
+func (s *Server) Violations() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]error(nil), s.violations...)
+}
+
+// serveHTTP authenticates, records and routes every request.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return
+	}
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+
+	if s.throttled > 0 {
+		s.throttled--
+		w.Header().Set("Retry-After", strconv.Itoa(int(s.retryAfter.Seconds())))
+		writeError(w, http.StatusTooManyRequests, "TooManyRequests", "Too many requests, retry later")
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+Token {
+		writeError(w, http.StatusUnauthorized, "TokenExpired", "Access token is missing or invalid")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	// The Fabric API is served next to the Power BI API, under /v1.
+	if segments[0] == "v1" {
+		if err := s.fabricSpec.ValidateRequest(r.Method, r.URL.Path, r.URL.Query(), body); err != nil {
+			s.violations = append(s.violations, err)
+			writeFabricError(w, http.StatusBadRequest, "InvalidInput", err.Error())
+			return
+		}
+		if s.fabricForbidden {
+			writeFabricError(w, http.StatusForbidden, "InsufficientScopes", "The caller does not have the required scopes to call the Fabric API")
+			return
+		}
+		s.routeFabricWorkspaces(w, r, segments[2:], body)
+		return
+	}
+
+	// Microsoft Graph is served next to the Power BI API, under /v1.0 outside of /v1.0/myorg.
+	if segments[0] == "v1.0" && (len(segments) < 2 || segments[1] != "myorg") {
+		if err := s.graphSpec.ValidateRequest(r.Method, r.URL.Path, r.URL.Query(), body); err != nil {
+			s.violations = append(s.violations, err)
+			writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+			return
+		}
+		s.routeGraph(w, r, segments[1:])
+		return
+	}
+
+	if err := s.spec.ValidateRequest(r.Method, r.URL.Path, r.URL.Query(), body); err != nil {
+		s.violations = append(s.violations, err)
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return
+	}
+
+	if len(segments) < 3 || segments[0] != "v1.0" || segments[1] != "myorg" {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	switch segments[2] {
+	case "admin":
+		s.routeAdmin(w, r, segments[3:], body)
+	case "groups":
+		s.routeGroups(w, r, segments[3:], body)
+	case "pipelines":
+		s.routePipelines(w, r, segments[3:], body)
+	case "dataflowStorageAccounts":
+		if len(segments) != 3 || r.Method != http.MethodGet {
+			writeNotFound(w, r.URL.Path)
+			return
+		}
+		s.listDataflowStorageAccounts(w)
+	default:
+		writeNotFound(w, r.URL.Path)
+	}
+}
+
+// readBody reads and closes the request body.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	defer r.Body.Close()
+
+	return io.ReadAll(r.Body)
+}
+
+// decodeBody unmarshals a JSON request body, writing a 400 response on failure.
+func decodeBody(w http.ResponseWriter, body []byte, v interface{}) bool {
+	if err := json.Unmarshal(body, v); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// pageBounds returns the bounds of the page selected by the $top and $skip query parameters
+// in a list of n items, writing a 400 response when they are invalid.
+func pageBounds(w http.ResponseWriter, query url.Values, n int) (int, int, bool) {
+	start, end := 0, n
+
+	if skip := query.Get("$skip"); skip != "" {
+		value, err := strconv.Atoi(skip)
+		if err != nil || value < 0 {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("invalid $skip value %q", skip))
+			return 0, 0, false
+		}
+		if value < n {
+			start = value
+		} else {
+			start = n
+		}
+	}
+
+	if top := query.Get("$top"); top != "" {
+		value, err := strconv.Atoi(top)
+		if err != nil || value < 1 {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("invalid $top value %q", top))
+			return 0, 0, false
+		}
+		if start+value < n {
+			end = start + value
+		}
+	}
+
+	return start, end, true
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a Power BI error payload.
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, apiError{Error: apiErrorDetail{Code: code, Message: message}})
+}
+
+// writeNotFound writes the error returned for unknown entities.
+func writeNotFound(w http.ResponseWriter, what string) {
+	writeError(w, http.StatusNotFound, "PowerBIEntityNotFound", fmt.Sprintf("%s was not found", what))
+}
+
+// newId returns a new random identifier, formatted like the Power BI IDs.
+func newId() string {
+	return uuid.NewString()
+}
@@ -0,0 +1,296 @@
+package fake_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// newClient creates a Power BI client pointing at the fake server.
+func newClient(t *testing.T, server *fake.Server) *powerbiapi.Client {
+	client, err := powerbiapi.NewClient(server.URL)
+	assert.NoError(t, err)
+
+	client.Credentials = powerbiapi.NewStaticTokenCredential(fake.Token)
+
+	return client
+}
+
+// TestGroupLifecycle creates, renames, reads and deletes a workspace, then checks it is gone.
+func TestGroupLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	client := newClient(t, server)
+
+	group, err := client.CreateGroup(ctx, "TF_LIFECYCLE")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, group.Id)
+
+	err = client.UpdateGroup(ctx, group.Id, &models.UpdateGroupRequest{Name: "TF_LIFECYCLE_RENAMED"})
+	assert.NoError(t, err)
+
+	group, err = client.GetGroup(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "TF_LIFECYCLE_RENAMED", group.Name)
+
+	err = client.DeleteGroup(ctx, group.Id)
+	assert.NoError(t, err)
+
+	_, err = client.GetGroup(ctx, group.Id)
+	assert.Error(t, err)
+
+	err = client.DeleteGroup(ctx, group.Id)
+	assert.Error(t, err)
+}
+
+// TestGroupDuplicateName checks that workspace names are unique, ignoring case.
+func TestGroupDuplicateName(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	client := newClient(t, server)
+
+	_, err := client.CreateGroup(ctx, "TF_DUPLICATE")
+	assert.NoError(t, err)
+
+	_, err = client.CreateGroup(ctx, "tf_duplicate")
+	assert.Error(t, err)
+
+	other, err := client.CreateGroup(ctx, "TF_OTHER")
+	assert.NoError(t, err)
+
+	err = client.UpdateGroup(ctx, other.Id, &models.UpdateGroupRequest{Name: "TF_DUPLICATE"})
+	assert.Error(t, err)
+}
+
+// TestGetGroupsFilterAndPaging checks the $filter, $top and $skip query parameters.
+func TestGetGroupsFilterAndPaging(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	client := newClient(t, server)
+
+	for _, name := range []string{"SALES - DEV", "SALES - PRD", "FINANCE", "O'BRIEN"} {
+		server.PutGroup(fake.Group{Name: name})
+	}
+
+	groups, err := client.GetGroups(ctx, "name eq 'FINANCE'", 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, groups.Value, 1)
+
+	groups, err = client.GetGroups(ctx, "name eq 'O''BRIEN'", 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, groups.Value, 1)
+
+	groups, err = client.GetGroups(ctx, "startswith(name,'sales')", 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, groups.Value, 2)
+
+	groups, err = client.GetGroups(ctx, "", 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, groups.ODataCount)
+	assert.Equal(t, "SALES - PRD", groups.Value[0].Name)
+
+	_, err = client.GetGroups(ctx, "capacityId eq 'x'", 0, 0)
+	assert.Error(t, err)
+}
+
+// TestGroupUsers adds, updates and removes workspace users.
+func TestGroupUsers(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	client := newClient(t, server)
+
+	group, err := client.CreateGroup(ctx, "TF_USERS")
+	assert.NoError(t, err)
+
+	user := &models.GroupUser{
+		EmailAddress:         "john.doe@example.com",
+		GroupUserAccessRight: models.GroupUserAccessRightViewer,
+		PrincipalType:        models.PrincipalTypeUser,
+	}
+	assert.NoError(t, client.AddGroupUser(ctx, group.Id, user))
+	assert.Error(t, client.AddGroupUser(ctx, group.Id, user))
+
+	user.GroupUserAccessRight = models.GroupUserAccessRightMember
+	assert.NoError(t, client.UpdateGroupUser(ctx, group.Id, user))
+
+	users, err := client.GetGroupUsers(ctx, group.Id)
+	assert.NoError(t, err)
+	// The creator of the workspace is an admin.
+	assert.Len(t, users.Value, 2)
+	assert.Equal(t, fake.CallerId, users.Value[0].Identifier)
+	assert.Equal(t, models.GroupUserAccessRightMember, users.Value[1].GroupUserAccessRight)
+
+	assert.NoError(t, client.DeleteUserGroup(ctx, group.Id, "john.doe@example.com"))
+	assert.Error(t, client.DeleteUserGroup(ctx, group.Id, "john.doe@example.com"))
+
+	_, err = client.GetGroupUsers(ctx, "00000000-0000-0000-0000-000000000000")
+	assert.Error(t, err)
+}
+
+// TestPipelineLifecycle creates, updates, reads and deletes a deployment pipeline.
+func TestPipelineLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	client := newClient(t, server)
+
+	pipeline, err := client.CreatePipeline(ctx, "TF_PIPELINE", "first")
+	assert.NoError(t, err)
+	assert.Empty(t, pipeline.Stages)
+
+	_, err = client.CreatePipeline(ctx, "TF_PIPELINE", "")
+	assert.Error(t, err)
+
+	_, err = client.UpdatePipeline(ctx, pipeline.Id, models.UpdatePipelineRequest{DisplayName: "TF_PIPELINE", Description: "second"})
+	assert.NoError(t, err)
+
+	pipeline, err = client.GetPipeline(ctx, pipeline.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", pipeline.Description)
+	assert.Len(t, pipeline.Stages, 3)
+
+	assert.NoError(t, client.DeletePipeline(ctx, pipeline.Id))
+
+	_, err = client.GetPipeline(ctx, pipeline.Id)
+	assert.Error(t, err)
+}
+
+// TestPipelineStageAssignment checks that a workspace assigned to a stage cannot be deleted until it is released.
+func TestPipelineStageAssignment(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	client := newClient(t, server)
+
+	group := server.PutGroup(fake.Group{Name: "TF_STAGE_DEV"})
+	pipeline := server.PutPipeline(fake.Pipeline{
+		DisplayName: "TF_STAGED",
+		Stages:      []fake.PipelineStage{{Order: 0, WorkspaceId: group.Id}},
+	})
+
+	read, err := client.GetPipeline(ctx, pipeline.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "TF_STAGE_DEV", read.Stages[0].WorkspaceName)
+
+	assert.Error(t, client.DeleteGroup(ctx, group.Id))
+
+	assert.True(t, server.RemovePipeline(pipeline.Id))
+	assert.NoError(t, client.DeleteGroup(ctx, group.Id))
+}
+
+// TestLogAnalyticsWorkspace checks that a Log Analytics workspace is assigned to a workspace on a dedicated capacity
+// through the admin update endpoint, and unassigned with a null value.
+func TestLogAnalyticsWorkspace(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	client := newClient(t, server)
+
+	shared := server.PutGroup(fake.Group{Name: "TF_SHARED"})
+	group := server.PutGroup(fake.Group{Name: "TF_DEDICATED", IsOnDedicatedCapacity: true})
+	logAnalytics := &models.AzureResource{SubscriptionId: "2a1b6d4e-6c4a-4b8e-9f0d-3c2e1b0a9f8e", ResourceGroup: "rg-monitoring", ResourceName: "law-powerbi"}
+
+	assert.Error(t, client.UpdateGroupLogAnalyticsWorkspaceAsAdmin(ctx, shared.Id, logAnalytics))
+
+	assert.NoError(t, client.UpdateGroupLogAnalyticsWorkspaceAsAdmin(ctx, group.Id, logAnalytics))
+
+	// The identifier of the Azure resource is required by the API, it is sent empty as the service assigns it.
+	requests := server.Requests()
+	assert.Equal(t, http.MethodPatch, requests[len(requests)-1].Method)
+	assert.Equal(t, "/v1.0/myorg/admin/groups/"+group.Id, requests[len(requests)-1].Path)
+	assert.JSONEq(t, `{
+		"id": "`+group.Id+`",
+		"logAnalyticsWorkspace": {
+			"id": "",
+			"subscriptionId": "2a1b6d4e-6c4a-4b8e-9f0d-3c2e1b0a9f8e",
+			"resourceGroup": "rg-monitoring",
+			"resourceName": "law-powerbi"
+		}
+	}`, string(requests[len(requests)-1].Body))
+
+	read, err := client.GetGroup(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "TF_DEDICATED", read.Name)
+	assert.Equal(t, "law-powerbi", read.LogAnalyticsWorkspace.ResourceName)
+	assert.NotEmpty(t, read.LogAnalyticsWorkspace.Id)
+
+	assert.NoError(t, client.UpdateGroupLogAnalyticsWorkspaceAsAdmin(ctx, group.Id, nil))
+
+	requests = server.Requests()
+	assert.JSONEq(t, `{"id": "`+group.Id+`", "logAnalyticsWorkspace": null}`, string(requests[len(requests)-1].Body))
+
+	stored, _ := server.Group(group.Id)
+	assert.Nil(t, stored.LogAnalyticsWorkspace)
+}
+
+// TestSpecificationViolation checks that a request breaking the API specification is rejected and kept as a violation.
+func TestSpecificationViolation(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.PutGroup(fake.Group{Id: "5bdd0b95-41a3-4bda-9a0f-22bd1bc5d7c6", Name: "TF_DEDICATED", IsOnDedicatedCapacity: true})
+
+	// A bare storage format instead of an UpdateGroupRequest object.
+	req, err := http.NewRequest(http.MethodPatch, server.URL+"/v1.0/myorg/groups/5bdd0b95-41a3-4bda-9a0f-22bd1bc5d7c6", strings.NewReader(`"Large"`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+fake.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	if violations := server.Violations(); assert.Len(t, violations, 1) {
+		assert.Contains(t, violations[0].Error(), "Groups_UpdateGroup")
+		assert.Contains(t, violations[0].Error(), `expected an object, got the string "Large"`)
+	}
+}
+
+// TestRestoreDeletedGroup deletes a workspace, lists it with the admin API and restores it.
+func TestRestoreDeletedGroup(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	client := newClient(t, server)
+
+	group, err := client.CreateGroup(ctx, "TF_RESTORE")
+	assert.NoError(t, err)
+	assert.NoError(t, client.DeleteGroup(ctx, group.Id))
+
+	groups, err := client.GetGroupsAsAdmin(ctx, "state eq 'Deleted'", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, groups.Value, 1)
+	assert.Equal(t, group.Id, groups.Value[0].Id)
+	assert.Equal(t, models.GroupStateDeleted, groups.Value[0].State)
+
+	_, err = client.GetGroup(ctx, group.Id)
+	assert.True(t, powerbiapi.IsNotFound(err))
+
+	// The name is taken by a new workspace, the deleted one must get another name.
+	_, err = client.CreateGroup(ctx, "TF_RESTORE")
+	assert.NoError(t, err)
+	err = client.RestoreDeletedGroupAsAdmin(ctx, group.Id, &models.GroupRestoreRequest{EmailAddress: "john.doe@example.com"})
+	assert.True(t, powerbiapi.IsAlreadyExists(err))
+
+	err = client.RestoreDeletedGroupAsAdmin(ctx, group.Id, &models.GroupRestoreRequest{EmailAddress: "john.doe@example.com", Name: "TF_RESTORED"})
+	assert.NoError(t, err)
+
+	restored, err := client.GetGroup(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "TF_RESTORED", restored.Name)
+	assert.Equal(t, []fake.GroupUser{{
+		EmailAddress:         "john.doe@example.com",
+		GroupUserAccessRight: "Admin",
+		Identifier:           "john.doe@example.com",
+		PrincipalType:        "User",
+	}}, server.GroupUsers(group.Id))
+
+	groups, err = client.GetGroupsAsAdmin(ctx, "", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, groups.Value, 2)
+	assert.Equal(t, models.GroupStateActive, groups.Value[1].State)
+	assert.Empty(t, server.DeletedGroups())
+}
@@ -0,0 +1,204 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// defaultItemsPageSize is the number of items per page of the Fabric list items endpoint, unless SetItemsPageSize is called.
+const defaultItemsPageSize = 100
+
+// fabricWorkspace is a group as returned by the Fabric workspaces API.
+type fabricWorkspace struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	CapacityId  string `json:"capacityId,omitempty"`
+}
+
+// fabricWorkspaceUpdateRequest is the body accepted by the update workspace endpoint of the Fabric API.
+type fabricWorkspaceUpdateRequest struct {
+	DisplayName *string `json:"displayName"`
+	Description *string `json:"description"`
+}
+
+// fabricItem is a group artifact as listed by the Fabric items API.
+type fabricItem struct {
+	Id          string `json:"id"`
+	Type        string `json:"type"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description,omitempty"`
+	WorkspaceId string `json:"workspaceId"`
+}
+
+// fabricItems is a page of the Fabric list items endpoint.
+type fabricItems struct {
+	Value             []fabricItem `json:"value"`
+	ContinuationToken string       `json:"continuationToken,omitempty"`
+	ContinuationUri   string       `json:"continuationUri,omitempty"`
+}
+
+// fabricError is the error payload returned by the Fabric REST API.
+type fabricError struct {
+	RequestId string `json:"requestId"`
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+// routeFabricWorkspaces dispatches the /v1/workspaces endpoints of the Fabric API.
+func (s *Server) routeFabricWorkspaces(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getFabricWorkspace(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		s.updateFabricWorkspace(w, segments[0], body)
+	case len(segments) == 2 && segments[1] == "items" && r.Method == http.MethodGet:
+		s.listFabricItems(w, r, segments[0])
+	default:
+		writeFabricError(w, http.StatusNotFound, "EntityNotFound", fmt.Sprintf("%s was not found", r.URL.Path))
+	}
+}
+
+// getFabricWorkspace implements GET /v1/workspaces/{workspaceId}.
+func (s *Server) getFabricWorkspace(w http.ResponseWriter, id string) {
+	g := s.findGroup(id)
+	if g == nil {
+		writeFabricError(w, http.StatusNotFound, "EntityNotFound", fmt.Sprintf("Workspace %s was not found", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newFabricWorkspace(g))
+}
+
+// updateFabricWorkspace implements PATCH /v1/workspaces/{workspaceId}.
+func (s *Server) updateFabricWorkspace(w http.ResponseWriter, id string, body []byte) {
+	g := s.findGroup(id)
+	if g == nil {
+		writeFabricError(w, http.StatusNotFound, "EntityNotFound", fmt.Sprintf("Workspace %s was not found", id))
+		return
+	}
+
+	var req fabricWorkspaceUpdateRequest
+	if !decodeBody(w, body, &req) {
+		return
+	}
+
+	if req.DisplayName != nil {
+		if other := s.findGroupByName(*req.DisplayName); other != nil && other != g {
+			writeFabricError(w, http.StatusConflict, "WorkspaceNameAlreadyExists", fmt.Sprintf("A workspace named %s already exists", *req.DisplayName))
+			return
+		}
+		g.Name = *req.DisplayName
+	}
+	if req.Description != nil {
+		if len(*req.Description) > 4000 {
+			writeFabricError(w, http.StatusBadRequest, "InvalidInput", "The description cannot contain more than 4000 characters")
+			return
+		}
+		g.Description = *req.Description
+	}
+
+	writeJSON(w, http.StatusOK, newFabricWorkspace(g))
+}
+
+// SetItemsPageSize sets the number of items per page of the Fabric list items endpoint.
+func (s *Server) SetItemsPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.itemsPageSize = n
+}
+
+// ForbidFabric makes every request to the Fabric API fail with 403 Forbidden while forbidden is true,
+// as when the token of the caller was issued for another API.
+func (s *Server) ForbidFabric(forbidden bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fabricForbidden = forbidden
+}
+
+// listFabricItems implements GET /v1/workspaces/{workspaceId}/items with the type and continuationToken
+// query parameters. The continuation token is the offset of the next page.
+func (s *Server) listFabricItems(w http.ResponseWriter, r *http.Request, id string) {
+	if s.findGroup(id) == nil {
+		writeFabricError(w, http.StatusNotFound, "EntityNotFound", fmt.Sprintf("Workspace %s was not found", id))
+		return
+	}
+
+	var items []fabricItem
+	itemType := r.URL.Query().Get("type")
+	for _, item := range s.fabricItems(id) {
+		if itemType == "" || item.Type == itemType {
+			items = append(items, item)
+		}
+	}
+
+	start := 0
+	if token := r.URL.Query().Get("continuationToken"); token != "" {
+		offset, err := strconv.Atoi(token)
+		if err != nil || offset < 0 || offset > len(items) {
+			writeFabricError(w, http.StatusBadRequest, "InvalidContinuationToken", fmt.Sprintf("Invalid continuation token %q", token))
+			return
+		}
+		start = offset
+	}
+
+	pageSize := s.itemsPageSize
+	if pageSize <= 0 {
+		pageSize = defaultItemsPageSize
+	}
+
+	page := fabricItems{Value: []fabricItem{}}
+	end := start + pageSize
+	if end >= len(items) {
+		end = len(items)
+	} else {
+		page.ContinuationToken = strconv.Itoa(end)
+		page.ContinuationUri = fmt.Sprintf("%s?continuationToken=%s", r.URL.Path, page.ContinuationToken)
+	}
+	page.Value = append(page.Value, items[start:end]...)
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+// fabricItems returns the contents of a group as Fabric items.
+func (s *Server) fabricItems(groupId string) []fabricItem {
+	contents := s.groupContents(groupId)
+
+	var items []fabricItem
+	for _, r := range contents.reports {
+		items = append(items, fabricItem{Id: r.Id, Type: "Report", DisplayName: r.Name, WorkspaceId: groupId})
+	}
+	for _, d := range contents.datasets {
+		items = append(items, fabricItem{Id: d.Id, Type: "SemanticModel", DisplayName: d.Name, WorkspaceId: groupId})
+	}
+	for _, d := range contents.dashboards {
+		items = append(items, fabricItem{Id: d.Id, Type: "Dashboard", DisplayName: d.DisplayName, WorkspaceId: groupId})
+	}
+	for _, d := range contents.dataflows {
+		items = append(items, fabricItem{Id: d.ObjectId, Type: "Dataflow", DisplayName: d.Name, Description: d.Description, WorkspaceId: groupId})
+	}
+	for _, d := range contents.datamarts {
+		items = append(items, fabricItem{Id: d.Id, Type: "Datamart", DisplayName: d.Name, Description: d.Description, WorkspaceId: groupId})
+	}
+	return items
+}
+
+// newFabricWorkspace returns a group as returned by the Fabric workspaces API.
+func newFabricWorkspace(g *Group) fabricWorkspace {
+	return fabricWorkspace{
+		Id:          g.Id,
+		DisplayName: g.Name,
+		Description: g.Description,
+		Type:        g.Type,
+		CapacityId:  g.CapacityId,
+	}
+}
+
+// writeFabricError writes an error response in the format of the Fabric REST API.
+func writeFabricError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, fabricError{RequestId: newId(), ErrorCode: code, Message: message})
+}
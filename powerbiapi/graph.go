@@ -0,0 +1,96 @@
+package powerbiapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// ErrGraphUnavailable is returned by the Microsoft Graph lookups when the client has no GraphURL.
+var ErrGraphUnavailable = errors.New("the Microsoft Graph API is not available, the client has no Graph URL")
+
+// GetGraphUser returns the specified user from Microsoft Graph, by object ID or user principal name.
+// https://learn.microsoft.com/en-us/graph/api/user-get
+func (c *Client) GetGraphUser(ctx context.Context, userId string) (*models.GraphUser, error) {
+	// GET https://graph.microsoft.com/v1.0/users/{userId}
+
+	user := &models.GraphUser{}
+
+	client, err := c.prepGraphRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetGraphUser: %w", err)
+	}
+
+	resp, err := client.SetResult(user).
+		SetQueryParam("$select", "id,displayName,mail,userPrincipalName").
+		Get(fmt.Sprintf("%s/v1.0/users/%s", c.GraphURL, url.PathEscape(userId)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get user: %w", newError(resp))
+	}
+
+	return user, nil
+}
+
+// ListGraphGroups returns the groups from Microsoft Graph matching the OData filter, such as "displayName eq 'Sales'".
+// Only the first page is returned, the filter is meant to select a few groups.
+// https://learn.microsoft.com/en-us/graph/api/group-list
+func (c *Client) ListGraphGroups(ctx context.Context, filter string) (*models.GraphGroups, error) {
+	// GET https://graph.microsoft.com/v1.0/groups
+
+	groups := &models.GraphGroups{}
+
+	client, err := c.prepGraphRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for ListGraphGroups: %w", err)
+	}
+
+	resp, err := client.SetResult(groups).
+		SetQueryParam("$filter", filter).
+		SetQueryParam("$select", "id,displayName,mail").
+		Get(fmt.Sprintf("%s/v1.0/groups", c.GraphURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to list groups: %w", newError(resp))
+	}
+
+	return groups, nil
+}
+
+// ListGraphServicePrincipals returns the service principals from Microsoft Graph matching the OData filter,
+// such as "appId eq '00000000-0000-0000-0000-000000000000'".
+// Only the first page is returned, the filter is meant to select a few service principals.
+// https://learn.microsoft.com/en-us/graph/api/serviceprincipal-list
+func (c *Client) ListGraphServicePrincipals(ctx context.Context, filter string) (*models.GraphServicePrincipals, error) {
+	// GET https://graph.microsoft.com/v1.0/servicePrincipals
+
+	servicePrincipals := &models.GraphServicePrincipals{}
+
+	client, err := c.prepGraphRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for ListGraphServicePrincipals: %w", err)
+	}
+
+	resp, err := client.SetResult(servicePrincipals).
+		SetQueryParam("$filter", filter).
+		SetQueryParam("$select", "id,appId,displayName").
+		Get(fmt.Sprintf("%s/v1.0/servicePrincipals", c.GraphURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service principals: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to list service principals: %w", newError(resp))
+	}
+
+	return servicePrincipals, nil
+}
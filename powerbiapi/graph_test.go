@@ -0,0 +1,91 @@
+package powerbiapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestGetGraphUser is a unit test function that tests the GetGraphUser method of the Client struct,
+// which finds a user by user principal name ignoring the case, or by object ID.
+func TestGetGraphUser(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	john := server.PutGraphUser(fake.GraphUser{UserPrincipalName: "john.doe@contoso.com", Mail: "john.doe@contoso.com"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	user, err := client.GetGraphUser(ctx, "John.Doe@contoso.com")
+	assert.NoError(t, err)
+	assert.Equal(t, john.Id, user.Id)
+	assert.Equal(t, "john.doe@contoso.com", user.UserPrincipalName)
+
+	user, err = client.GetGraphUser(ctx, john.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "john.doe@contoso.com", user.UserPrincipalName)
+
+	_, err = client.GetGraphUser(ctx, "jane.doe@contoso.com")
+	assert.True(t, IsNotFound(err))
+	assert.True(t, HasErrorCode(err, "Request_ResourceNotFound"))
+}
+
+// TestListGraphGroups is a unit test function that tests the ListGraphGroups and ListGraphServicePrincipals
+// methods of the Client struct, which select the directory objects with an OData filter.
+func TestListGraphGroups(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	sales := server.PutGraphGroup(fake.GraphGroup{DisplayName: "Sales"})
+	server.PutGraphGroup(fake.GraphGroup{DisplayName: "Sales Managers"})
+	app := server.PutGraphServicePrincipal(fake.GraphServicePrincipal{DisplayName: "terraform"})
+	server.PutGraphServicePrincipal(fake.GraphServicePrincipal{DisplayName: "other"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	groups, err := client.ListGraphGroups(ctx, "displayName eq 'Sales'")
+	assert.NoError(t, err)
+	if assert.Len(t, groups.Value, 1) {
+		assert.Equal(t, sales.Id, groups.Value[0].Id)
+	}
+
+	servicePrincipals, err := client.ListGraphServicePrincipals(ctx, "appId eq '"+app.AppId+"'")
+	assert.NoError(t, err)
+	if assert.Len(t, servicePrincipals.Value, 1) {
+		assert.Equal(t, app.Id, servicePrincipals.Value[0].Id)
+	}
+
+	servicePrincipals, err = client.ListGraphServicePrincipals(ctx, "appId eq 'f089354e-8366-4e18-aea3-4cb4a3a50b48'")
+	assert.NoError(t, err)
+	assert.Empty(t, servicePrincipals.Value)
+}
+
+// TestGraph_Unavailable is a unit test function that verifies that the Microsoft Graph lookups fail without
+// a Graph URL, which is only set for the default Power BI host, and that no request is sent.
+func TestGraph_Unavailable(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+	client.GraphURL = ""
+
+	_, err = client.GetGraphUser(ctx, "john.doe@contoso.com")
+	assert.ErrorIs(t, err, ErrGraphUnavailable)
+
+	_, err = client.ListGraphGroups(ctx, "displayName eq 'Sales'")
+	assert.ErrorIs(t, err, ErrGraphUnavailable)
+
+	assert.Empty(t, server.Requests())
+
+	client, err = NewClient("")
+	assert.NoError(t, err)
+	assert.Equal(t, GraphBaseURL, client.GraphURL)
+
+	client, err = NewClient("https://api.powerbigov.us")
+	assert.NoError(t, err)
+	assert.Empty(t, client.GraphURL)
+}
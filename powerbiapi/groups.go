@@ -1,33 +1,35 @@
 package powerbiapi
 
 import (
+	"context"
 	"fmt"
 	"strconv"
-	"terraform-provider-powerbi/internal/powerbiapi/models"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
 )
 
 // AddGroupUser adds a user/group/app to a group.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/add-group-user
-func (c *Client) AddGroupUser(groupId string, groupUserAccessRight *models.GroupUser) error {
+func (c *Client) AddGroupUser(ctx context.Context, groupId string, groupUserAccessRight *models.GroupUser) error {
 	// POST https://api.powerbi.com/v1.0/myorg/groups/{groupId}/users
 
-	client, err := c.prepRequest()
+	client, err := c.prepRequest(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to prepare the request for AddGroupUser: %v", err)
+		return fmt.Errorf("failed to prepare the request for AddGroupUser: %w", err)
 	}
 
 	body, err := groupUserAccessRight.Validate()
 	if err != nil {
-		return fmt.Errorf("failed to validate group user: %v", err)
+		return fmt.Errorf("failed to validate group user: %w", err)
 	}
 
 	resp, err := client.SetBody(body).Post(fmt.Sprintf("/v1.0/myorg/groups/%s/users", groupId))
 	if err != nil {
-		return fmt.Errorf("failed to add group user: %v", err)
+		return fmt.Errorf("failed to add group user: %w", err)
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("failed to add group user: %v", resp.Error())
+		return fmt.Errorf("failed to add group user: %w", newError(resp))
 	}
 
 	return nil
@@ -35,15 +37,15 @@ func (c *Client) AddGroupUser(groupId string, groupUserAccessRight *models.Group
 
 // CreateGroup creates a new group.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/create-group
-func (c *Client) CreateGroup(groupName string) (*models.Group, error) {
+func (c *Client) CreateGroup(ctx context.Context, groupName string) (*models.Group, error) {
 	// POST https://api.powerbi.com/v1.0/myorg/groups
 
 	var err error
 	group := &models.Group{}
 
-	client, err := c.prepRequest()
+	client, err := c.prepRequest(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare the request for CreateGroup: %v", err)
+		return nil, fmt.Errorf("failed to prepare the request for CreateGroup: %w", err)
 	}
 
 	resp, err := client.SetResult(group).
@@ -51,11 +53,11 @@ func (c *Client) CreateGroup(groupName string) (*models.Group, error) {
 		SetBody(&models.GroupCreationRequest{Name: groupName}).
 		Post("/v1.0/myorg/groups")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create group: %v", err)
+		return nil, fmt.Errorf("failed to create group: %w", err)
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("failed to create group: %v", resp.Error())
+		return nil, fmt.Errorf("failed to create group: %w", newError(resp))
 	}
 
 	return group, nil
@@ -63,22 +65,22 @@ func (c *Client) CreateGroup(groupName string) (*models.Group, error) {
 
 // DeleteGroup deletes a group by its ID.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/delete-group
-func (c *Client) DeleteGroup(groupId string) error {
+func (c *Client) DeleteGroup(ctx context.Context, groupId string) error {
 	// DELETE https://api.powerbi.com/v1.0/myorg/groups/{groupId}
 	var err error
 
-	client, err := c.prepRequest()
+	client, err := c.prepRequest(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to prepare the request for DeleteGroup: %v", err)
+		return fmt.Errorf("failed to prepare the request for DeleteGroup: %w", err)
 	}
 
 	resp, err := client.Delete(fmt.Sprintf("/v1.0/myorg/groups/%s", groupId))
 	if err != nil {
-		return fmt.Errorf("failed to delete group: %v", err)
+		return fmt.Errorf("failed to delete group: %w", err)
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("failed to delete group: %v", resp.Error())
+		return fmt.Errorf("failed to delete group: %w", newError(resp))
 	}
 
 	return nil
@@ -87,20 +89,20 @@ func (c *Client) DeleteGroup(groupId string) error {
 // DeleteUserGroup deletes a user from a group.
 // user is the email address of the user or object ID of the service principal to delete
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/delete-user-in-group
-func (c *Client) DeleteUserGroup(groupId string, user string) error {
+func (c *Client) DeleteUserGroup(ctx context.Context, groupId string, user string) error {
 
-	client, err := c.prepRequest()
+	client, err := c.prepRequest(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to prepare the request for DeleteUserGroup: %v", err)
+		return fmt.Errorf("failed to prepare the request for DeleteUserGroup: %w", err)
 	}
 
 	resp, err := client.Delete(fmt.Sprintf("/v1.0/myorg/groups/%s/users/%s", groupId, user))
 	if err != nil {
-		return fmt.Errorf("failed to delete user from group: %v", err)
+		return fmt.Errorf("failed to delete user from group: %w", err)
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("failed to delete user from group: %v", resp.Error())
+		return fmt.Errorf("failed to delete user from group: %w", newError(resp))
 	}
 
 	return nil
@@ -108,24 +110,24 @@ func (c *Client) DeleteUserGroup(groupId string, user string) error {
 
 // GetGroup retrieves a group by its ID.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/get-group
-func (c *Client) GetGroup(groupId string) (*models.Group, error) {
+func (c *Client) GetGroup(ctx context.Context, groupId string) (*models.Group, error) {
 	// GET https://api.powerbi.com/v1.0/myorg/groups/{groupId}
 
 	var err error
 	group := &models.Group{}
 
-	client, err := c.prepRequest()
+	client, err := c.prepRequest(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare the request for GetGroups: %v", err)
+		return nil, fmt.Errorf("failed to prepare the request for GetGroups: %w", err)
 	}
 
 	resp, err := client.SetResult(group).Get(fmt.Sprintf("/v1.0/myorg/groups/%s", groupId))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get group: %v", err)
+		return nil, fmt.Errorf("failed to get group: %w", err)
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get group: %v", resp.Error())
+		return nil, fmt.Errorf("failed to get group: %w", newError(resp))
 	}
 
 	return group, nil
@@ -133,24 +135,36 @@ func (c *Client) GetGroup(groupId string) (*models.Group, error) {
 
 // GetGroupUsers retrieves a list of users, groups, and service principals in a group.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/get-group-users
-func (c *Client) GetGroupUsers(groupId string) (*models.GroupUsers, error) {
+func (c *Client) GetGroupUsers(ctx context.Context, groupId string) (*models.GroupUsers, error) {
 	// GET https://api.powerbi.com/v1.0/myorg/groups/{groupId}/users
 
+	return c.getGroupUsersPage(ctx, groupId, 0, 0)
+}
+
+// getGroupUsersPage retrieves a page of the users of a group, all of them when top is zero.
+func (c *Client) getGroupUsersPage(ctx context.Context, groupId string, top int, skip int) (*models.GroupUsers, error) {
 	var err error
 	groupUsers := &models.GroupUsers{}
 
-	client, err := c.prepRequest()
+	client, err := c.prepRequest(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare the request for GetGroupUsers: %v", err)
+		return nil, fmt.Errorf("failed to prepare the request for GetGroupUsers: %w", err)
+	}
+
+	if top > 0 {
+		client.SetQueryParam("$top", strconv.Itoa(top))
+	}
+	if skip > 0 {
+		client.SetQueryParam("$skip", strconv.Itoa(skip))
 	}
 
 	resp, err := client.SetResult(groupUsers).Get(fmt.Sprintf("/v1.0/myorg/groups/%s/users", groupId))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get group users: %v", err)
+		return nil, fmt.Errorf("failed to get group users: %w", err)
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get group users: %v", resp.Error())
+		return nil, fmt.Errorf("failed to get group users: %w", newError(resp))
 	}
 
 	return groupUsers, nil
@@ -158,15 +172,15 @@ func (c *Client) GetGroupUsers(groupId string) (*models.GroupUsers, error) {
 
 // GetGroups retrieves a list of groups.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/get-groups
-func (c *Client) GetGroups(filter string, top int, skip int) (*models.Groups, error) {
+func (c *Client) GetGroups(ctx context.Context, filter string, top int, skip int) (*models.Groups, error) {
 	// GET https://api.powerbi.com/v1.0/myorg/groups
 
 	var err error
 	groups := &models.Groups{}
 
-	client, err := c.prepRequest()
+	client, err := c.prepRequest(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare the request for GetGroups: %v", err)
+		return nil, fmt.Errorf("failed to prepare the request for GetGroups: %w", err)
 	}
 
 	if filter != "" {
@@ -181,39 +195,42 @@ func (c *Client) GetGroups(filter string, top int, skip int) (*models.Groups, er
 
 	resp, err := client.SetResult(&groups).Get("/v1.0/myorg/groups")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get groups: %v", err)
+		return nil, fmt.Errorf("failed to get groups: %w", err)
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get groups: %v", resp.Error())
+		return nil, fmt.Errorf("failed to get groups: %w", newError(resp))
 	}
 
 	return groups, nil
 }
 
-// UpdateGroup updates a specified workspace.
+// UpdateGroup updates a specified workspace. No request is sent when updateGroupRequest sets no property.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/update-group
-func (c *Client) UpdateGroup(groupId string, updateGroupRequest *models.UpdateGroupRequest) error {
+func (c *Client) UpdateGroup(ctx context.Context, groupId string, updateGroupRequest *models.UpdateGroupRequest) error {
 	// PATCH https://api.powerbi.com/v1.0/myorg/groups/{groupId}
 
 	var err error
 
-	client, err := c.prepRequest()
-	if err != nil {
-		return fmt.Errorf("failed to prepare the request for GetGroups: %v", err)
+	body := updateGroupRequest.Validate()
+	if body == nil {
+		return nil
 	}
 
-	body := updateGroupRequest.Validate()
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare the request for UpdateGroup: %w", err)
+	}
 
 	resp, err := client.
 		SetBody(body).
 		Patch(fmt.Sprintf("/v1.0/myorg/groups/%s", groupId))
 	if err != nil {
-		return fmt.Errorf("failed to update groups: %v", err)
+		return fmt.Errorf("failed to update groups: %w", err)
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("failed to get groups: [%v] %s", resp.StatusCode(), resp.String())
+		return fmt.Errorf("failed to update group: %w", newError(resp))
 	}
 
 	return nil
@@ -221,30 +238,30 @@ func (c *Client) UpdateGroup(groupId string, updateGroupRequest *models.UpdateGr
 
 // UpdateGroupUser updates the specified user permissions to the specified workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups/update-group-user
-func (c *Client) UpdateGroupUser(groupId string, groupUserAccessRight *models.GroupUser) error {
+func (c *Client) UpdateGroupUser(ctx context.Context, groupId string, groupUserAccessRight *models.GroupUser) error {
 	// PUT https://api.powerbi.com/v1.0/myorg/groups/{groupId}/users
 
 	var err error
 
-	client, err := c.prepRequest()
+	client, err := c.prepRequest(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to prepare the request for GetGroups: %v", err)
+		return fmt.Errorf("failed to prepare the request for GetGroups: %w", err)
 	}
 
 	body, err := groupUserAccessRight.Validate()
 	if err != nil {
-		return fmt.Errorf("failed to validate group user: %v", err)
+		return fmt.Errorf("failed to validate group user: %w", err)
 	}
 
 	resp, err := client.
 		SetBody(body).
 		Put(fmt.Sprintf("/v1.0/myorg/groups/%s/users", groupId))
 	if err != nil {
-		return fmt.Errorf("failed to update groups users: %v", err)
+		return fmt.Errorf("failed to update groups users: %w", err)
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("failed to get groups users: %v", resp.Error())
+		return fmt.Errorf("failed to get groups users: %w", newError(resp))
 	}
 
 	return nil
@@ -1,18 +1,25 @@
 package powerbiapi
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"terraform-provider-powerbi/internal/powerbiapi/models"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
 )
 
 // TestAddGroupUser_User is a unit test function that tests the AddGroupUser method of the Client struct.
 // It verifies that the correct request URL and method are used, and that the function returns no error.
 func TestAddGroupUser_User(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -29,7 +36,7 @@ func TestAddGroupUser_User(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Prepare Request
@@ -40,7 +47,7 @@ func TestAddGroupUser_User(t *testing.T) {
 	}
 
 	// Call the GetGroup function
-	err = client.AddGroupUser("65d6aaca-2275-4e70-bb4f-91dde4dc6c99", groupUserAccess)
+	err = client.AddGroupUser(ctx, "65d6aaca-2275-4e70-bb4f-91dde4dc6c99", groupUserAccess)
 
 	// Check the result
 	assert.NoError(t, err)
@@ -49,6 +56,7 @@ func TestAddGroupUser_User(t *testing.T) {
 // TestAddGroupUser_Group is a unit test function that tests the AddGroupUser method of the Client struct.
 // It verifies that the correct request URL and method are used, and that the function returns no error.
 func TestAddGroupUser_Group(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -65,7 +73,7 @@ func TestAddGroupUser_Group(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Prepare Request
@@ -76,15 +84,81 @@ func TestAddGroupUser_Group(t *testing.T) {
 	}
 
 	// Call the GetGroup function
-	err = client.AddGroupUser("ac653691-1af8-4be1-8468-9d73cdcc1250", groupUserAccess)
+	err = client.AddGroupUser(ctx, "ac653691-1af8-4be1-8468-9d73cdcc1250", groupUserAccess)
 
 	// Check the result
 	assert.NoError(t, err)
 }
 
+// TestAddGroupUser_Profile tests that a service principal profile is assigned to a workspace with its app.
+func TestAddGroupUser_Profile(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "TF_WORKSPACE"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	err = client.AddGroupUser(ctx, group.Id, &models.GroupUser{
+		Identifier:           "f1e0b2a3-7c5d-4e6f-8a9b-0c1d2e3f4a5b",
+		GroupUserAccessRight: models.GroupUserAccessRightContributor,
+		PrincipalType:        models.PrincipalTypeApp,
+		Profile:              models.ServicePrincipalProfile{Id: "2a6f7e3c-51b4-4d8a-9c0e-3f2b1a0d9e8c", DisplayName: "Tenant A"},
+	})
+	assert.NoError(t, err)
+
+	users := server.GroupUsers(group.Id)
+	if assert.Len(t, users, 1) && assert.NotNil(t, users[0].Profile) {
+		assert.Equal(t, "2a6f7e3c-51b4-4d8a-9c0e-3f2b1a0d9e8c", users[0].Profile.Id)
+	}
+}
+
+// TestAddGroupUser_Invalid tests that an invalid group user is rejected before any request is sent,
+// with an error naming the invalid property.
+func TestAddGroupUser_Invalid(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		user     models.GroupUser
+		property string
+	}{
+		{"no principal", models.GroupUser{PrincipalType: models.PrincipalTypeUser, GroupUserAccessRight: models.GroupUserAccessRightAdmin}, "emailAddress"},
+		{"user by identifier", models.GroupUser{PrincipalType: models.PrincipalTypeUser, EmailAddress: "john.doe@example.com", Identifier: "john.doe@example.com", GroupUserAccessRight: models.GroupUserAccessRightAdmin}, "identifier"},
+		{"group by email", models.GroupUser{PrincipalType: models.PrincipalTypeGroup, EmailAddress: "readers@example.com", GroupUserAccessRight: models.GroupUserAccessRightViewer}, "identifier"},
+		{"group with profile", models.GroupUser{PrincipalType: models.PrincipalTypeGroup, Identifier: "796131c3-8d85-44e1-bdfc-88ad8ba46520", Profile: models.ServicePrincipalProfile{Id: "2a6f7e3c-51b4-4d8a-9c0e-3f2b1a0d9e8c"}, GroupUserAccessRight: models.GroupUserAccessRightViewer}, "profile"},
+		{"profile without id", models.GroupUser{PrincipalType: models.PrincipalTypeApp, Identifier: "f1e0b2a3-7c5d-4e6f-8a9b-0c1d2e3f4a5b", Profile: models.ServicePrincipalProfile{DisplayName: "Tenant A"}, GroupUserAccessRight: models.GroupUserAccessRightViewer}, "profile"},
+		{"no principal type", models.GroupUser{Identifier: "796131c3-8d85-44e1-bdfc-88ad8ba46520", GroupUserAccessRight: models.GroupUserAccessRightViewer}, "principalType"},
+		{"whole organization", models.GroupUser{PrincipalType: models.PrincipalTypeNone, GroupUserAccessRight: models.GroupUserAccessRightViewer}, "principalType"},
+		{"no access right", models.GroupUser{PrincipalType: models.PrincipalTypeGroup, Identifier: "796131c3-8d85-44e1-bdfc-88ad8ba46520"}, "groupUserAccessRight"},
+		{"access right None", models.GroupUser{PrincipalType: models.PrincipalTypeGroup, Identifier: "796131c3-8d85-44e1-bdfc-88ad8ba46520", GroupUserAccessRight: models.GroupUserAccessRightNone}, "groupUserAccessRight"},
+		{"unknown access right", models.GroupUser{PrincipalType: models.PrincipalTypeGroup, Identifier: "796131c3-8d85-44e1-bdfc-88ad8ba46520", GroupUserAccessRight: "Owner"}, "groupUserAccessRight"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := tt.user
+			err := client.AddGroupUser(ctx, "ac653691-1af8-4be1-8468-9d73cdcc1250", &user)
+
+			var userErr *models.GroupUserError
+			if assert.ErrorAs(t, err, &userErr) {
+				assert.Equal(t, tt.property, userErr.Property)
+			}
+		})
+	}
+}
+
 // TestCreateGroup is a unit test function that tests the CreateGroup function of the client.
 // It creates a test server, sends a mock request to the server, and checks the response.
 func TestCreateGroup(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -112,11 +186,11 @@ func TestCreateGroup(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the GetGroup function
-	group, err := client.CreateGroup("UNIT_TEST")
+	group, err := client.CreateGroup(ctx, "UNIT_TEST")
 
 	// Check the result
 	assert.NoError(t, err)
@@ -126,6 +200,7 @@ func TestCreateGroup(t *testing.T) {
 // TestDeleteGroup is a unit test function that tests the DeleteGroup function.
 // It creates a test server, sends a DELETE request to the server, and checks the response.
 func TestDeleteGroup(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -142,11 +217,11 @@ func TestDeleteGroup(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the DeleteGroup function
-	err = client.DeleteGroup("878026dd-3e07-402e-a38f-9a2a0356d83f")
+	err = client.DeleteGroup(ctx, "878026dd-3e07-402e-a38f-9a2a0356d83f")
 
 	// Check the result
 	assert.NoError(t, err)
@@ -157,6 +232,7 @@ func TestDeleteGroup(t *testing.T) {
 // The function verifies that the request URL and method are correct, and that the response status code is HTTP 200 OK.
 // Finally, it checks if the DeleteUserGroup method returns an error or not.
 func TestDeleteUserGroup(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -173,11 +249,11 @@ func TestDeleteUserGroup(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the DeleteGroup function
-	err = client.DeleteUserGroup("ac653691-1af8-4be1-8468-9d73cdcc1250", "796131c3-8d85-44e1-bdfc-88ad8ba46520")
+	err = client.DeleteUserGroup(ctx, "ac653691-1af8-4be1-8468-9d73cdcc1250", "796131c3-8d85-44e1-bdfc-88ad8ba46520")
 
 	// Check the result
 	assert.NoError(t, err)
@@ -188,6 +264,7 @@ func TestDeleteUserGroup(t *testing.T) {
 // The function verifies that the request URL, method, and response are correct.
 // It also checks if the returned group object has the expected ID and name.
 func TestGetGroup(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -216,11 +293,11 @@ func TestGetGroup(t *testing.T) {
 	// host := server.URL.
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the GetGroup function
-	group, err := client.GetGroup("465d5aaa-c6a7-4add-a618-dc76d27a00ca")
+	group, err := client.GetGroup(ctx, "465d5aaa-c6a7-4add-a618-dc76d27a00ca")
 
 	// Check the result
 	assert.NoError(t, err)
@@ -230,6 +307,7 @@ func TestGetGroup(t *testing.T) {
 
 // TestGetGroupUsers tests the GetGroupUsers function.
 func TestGetGroupUsers(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -259,11 +337,11 @@ func TestGetGroupUsers(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the GetGroup function
-	_, err = client.GetGroupUsers("ac653691-1af8-4be1-8468-9d73cdcc1250")
+	_, err = client.GetGroupUsers(ctx, "ac653691-1af8-4be1-8468-9d73cdcc1250")
 
 	// Check the result
 	assert.NoError(t, err)
@@ -272,6 +350,7 @@ func TestGetGroupUsers(t *testing.T) {
 // TestGetGroups is a unit test function that tests the GetGroups function of the client.
 // It creates a test server, sends a mock response, and checks the result.
 func TestGetGroups(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -344,11 +423,11 @@ func TestGetGroups(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the GetGroup function
-	group, err := client.GetGroups("", 0, 0)
+	group, err := client.GetGroups(ctx, "", 0, 0)
 
 	// Check the result
 	assert.Equal(t, 6, group.ODataCount)
@@ -360,6 +439,7 @@ func TestGetGroups(t *testing.T) {
 // The function verifies that the request URL, method, and response status code are correct.
 // It also checks if the client successfully updates the group and returns no error.
 func TestUpdateGroups(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -377,22 +457,69 @@ func TestUpdateGroups(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the GetGroup function
-	err = client.UpdateGroup("370e64cb-da5a-40df-a85e-4499f074b0cf", &models.UpdateGroupRequest{Name: "TF_WORKSPACE_POSTMAN"})
+	err = client.UpdateGroup(ctx, "370e64cb-da5a-40df-a85e-4499f074b0cf", &models.UpdateGroupRequest{Name: "TF_WORKSPACE_POSTMAN"})
 
 	// Check the result
 	assert.NoError(t, err)
 }
 
+// TestUpdateGroup_DefaultDatasetStorageFormat is a unit test function that tests the UpdateGroup method of the client
+// against the fake server. It verifies that the name and the default dataset storage format are updated together,
+// or alone, with request bodies matching the API specification.
+func TestUpdateGroup_DefaultDatasetStorageFormat(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "TF_WORKSPACE", IsOnDedicatedCapacity: true, DefaultDatasetStorageFormat: "Small"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	err = client.UpdateGroup(ctx, group.Id, &models.UpdateGroupRequest{
+		Name:                        "TF_WORKSPACE_RENAMED",
+		DefaultDatasetStorageFormat: models.DefaultDatasetStorageFormatLarge,
+	})
+	assert.NoError(t, err)
+
+	updated, _ := server.Group(group.Id)
+	assert.Equal(t, "TF_WORKSPACE_RENAMED", updated.Name)
+	assert.Equal(t, "Large", updated.DefaultDatasetStorageFormat)
+
+	err = client.UpdateGroup(ctx, group.Id, &models.UpdateGroupRequest{DefaultDatasetStorageFormat: models.DefaultDatasetStorageFormatSmall})
+	assert.NoError(t, err)
+
+	updated, _ = server.Group(group.Id)
+	assert.Equal(t, "TF_WORKSPACE_RENAMED", updated.Name)
+	assert.Equal(t, "Small", updated.DefaultDatasetStorageFormat)
+}
+
+// TestUpdateGroup_Empty tests that an update request setting no property sends no request,
+// rather than a blank name, and does not even get a token.
+func TestUpdateGroup_Empty(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+	client.Credentials = failingCredential{}
+
+	err = client.UpdateGroup(ctx, "370e64cb-da5a-40df-a85e-4499f074b0cf", &models.UpdateGroupRequest{})
+	assert.NoError(t, err)
+}
+
 // TestUpdateGroupUser_User is a unit test function that tests the UpdateGroupUser method of the Client struct.
 // It verifies that the correct request is made to update a user in a group and checks the response status code.
 // The test server is created to mock the API endpoint, and the client is created with the test server URL.
 // The group user access details are prepared, and the UpdateGroupUser method is called.
 // Finally, it asserts that there is no error returned from the method.
 func TestUpdateGroupUser_User(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -409,7 +536,7 @@ func TestUpdateGroupUser_User(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Prepare Request
@@ -420,7 +547,7 @@ func TestUpdateGroupUser_User(t *testing.T) {
 	}
 
 	// Call the GetGroup function
-	err = client.UpdateGroupUser("ac653691-1af8-4be1-8468-9d73cdcc1250", groupUserAccess)
+	err = client.UpdateGroupUser(ctx, "ac653691-1af8-4be1-8468-9d73cdcc1250", groupUserAccess)
 
 	// Check the result
 	assert.NoError(t, err)
@@ -432,6 +559,7 @@ func TestUpdateGroupUser_User(t *testing.T) {
 // It then creates a client with the test server URL, prepares the request payload, and calls the UpdateGroupUser method.
 // Finally, it asserts that no error occurred during the API call.
 func TestUpdateGroupUser_Group(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -448,7 +576,7 @@ func TestUpdateGroupUser_Group(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host = "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Prepare Request
@@ -459,8 +587,16 @@ func TestUpdateGroupUser_Group(t *testing.T) {
 	}
 
 	// Call the GetGroup function
-	err = client.UpdateGroupUser("ac653691-1af8-4be1-8468-9d73cdcc1250", groupUserAccess)
+	err = client.UpdateGroupUser(ctx, "ac653691-1af8-4be1-8468-9d73cdcc1250", groupUserAccess)
 
 	// Check the result
 	assert.NoError(t, err)
 }
+
+// failingCredential is a credential that never returns a token, for the calls which must not get one.
+type failingCredential struct{}
+
+// GetToken implements azcore.TokenCredential.
+func (failingCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{}, errors.New("no token expected")
+}
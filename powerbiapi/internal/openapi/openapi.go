@@ -0,0 +1,392 @@
+// Package openapi holds the snapshot of the Power BI REST API specification and validates requests against it.
+//
+// The snapshot, swagger.json, is trimmed to the operations used by the provider. It is the source of the
+// generated models and the contract checked by the fake Power BI server: every request the client sends
+// in the tests must match an operation of the snapshot, with valid path and query parameters and body.
+// The few operations of the Fabric REST API used by the provider have their own snapshot, fabric.json,
+// and so have the Microsoft Graph lookups resolving principals, graph.json.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed swagger.json
+var specification []byte
+
+//go:embed fabric.json
+var fabricSpecification []byte
+
+//go:embed graph.json
+var graphSpecification []byte
+
+// Spec is the part of a swagger 2.0 specification used to validate requests.
+type Spec struct {
+	BasePath    string                           `json:"basePath"`
+	Paths       map[string]map[string]*Operation `json:"paths"`
+	Definitions map[string]*Schema               `json:"definitions"`
+}
+
+// Operation is an operation of the specification, such as GET /groups.
+type Operation struct {
+	OperationId string       `json:"operationId"`
+	Parameters  []*Parameter `json:"parameters"`
+}
+
+// Parameter is a path, query or body parameter of an operation.
+type Parameter struct {
+	Name     string   `json:"name"`
+	In       string   `json:"in"`
+	Required bool     `json:"required"`
+	Type     string   `json:"type"`
+	Format   string   `json:"format"`
+	Enum     []string `json:"enum"`
+	Minimum  *float64 `json:"minimum"`
+	Schema   *Schema  `json:"schema"`
+}
+
+// Schema is a swagger 2.0 schema object.
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Format     string             `json:"format"`
+	Required   []string           `json:"required"`
+	Properties map[string]*Schema `json:"properties"`
+	Items      *Schema            `json:"items"`
+	Enum       []string           `json:"enum"`
+}
+
+var (
+	loadOnce sync.Once
+	loaded   *Spec
+	loadErr  error
+
+	loadFabricOnce sync.Once
+	loadedFabric   *Spec
+	loadFabricErr  error
+
+	loadGraphOnce sync.Once
+	loadedGraph   *Spec
+	loadGraphErr  error
+)
+
+// uuidPattern matches the identifiers of the Power BI entities.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Load returns the embedded specification. It is parsed once.
+func Load() (*Spec, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = Parse(specification)
+	})
+	return loaded, loadErr
+}
+
+// LoadFabric returns the embedded specification of the Fabric REST API. It is parsed once.
+func LoadFabric() (*Spec, error) {
+	loadFabricOnce.Do(func() {
+		loadedFabric, loadFabricErr = Parse(fabricSpecification)
+	})
+	return loadedFabric, loadFabricErr
+}
+
+// LoadGraph returns the embedded specification of the Microsoft Graph REST API. It is parsed once.
+func LoadGraph() (*Spec, error) {
+	loadGraphOnce.Do(func() {
+		loadedGraph, loadGraphErr = Parse(graphSpecification)
+	})
+	return loadedGraph, loadGraphErr
+}
+
+// Parse parses a swagger 2.0 specification.
+func Parse(content []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse the specification: %v", err)
+	}
+	return &spec, nil
+}
+
+// ValidateRequest checks a request against the specification: the path, relative to the host, must match
+// an operation for the method, its path and query parameters must be valid and its JSON body must match
+// the schema of the body parameter. All the violations found are returned, joined.
+func (s *Spec) ValidateRequest(method string, path string, query url.Values, body []byte) error {
+	// The base path must end on a segment boundary, "/v1" does not contain "/v1.0/myorg/groups".
+	relative, ok := strings.CutPrefix(path, s.BasePath)
+	if !ok || (relative != "" && !strings.HasPrefix(relative, "/")) {
+		return fmt.Errorf("%s %s: path is outside of %s", method, path, s.BasePath)
+	}
+
+	template, pathParams := s.matchPath(relative)
+	if template == "" {
+		return fmt.Errorf("%s %s: no path of the specification matches", method, path)
+	}
+
+	operation := s.Paths[template][strings.ToLower(method)]
+	if operation == nil {
+		return fmt.Errorf("%s %s: method is not allowed on %s", method, path, template)
+	}
+
+	var errs []error
+	report := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s %s (%s): %s", method, path, operation.OperationId, fmt.Sprintf(format, args...)))
+	}
+
+	var bodyParam *Parameter
+	known := map[string]bool{}
+	for _, param := range operation.Parameters {
+		switch param.In {
+		case "path":
+			if err := param.validateValue(pathParams[param.Name]); err != nil {
+				report("path parameter %s: %v", param.Name, err)
+			}
+		case "query":
+			known[param.Name] = true
+			values, present := query[param.Name]
+			if !present {
+				if param.Required {
+					report("missing required query parameter %s", param.Name)
+				}
+				continue
+			}
+			for _, value := range values {
+				if err := param.validateValue(value); err != nil {
+					report("query parameter %s: %v", param.Name, err)
+				}
+			}
+		case "body":
+			bodyParam = param
+		}
+	}
+
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !known[name] {
+			report("unknown query parameter %s", name)
+		}
+	}
+
+	empty := len(strings.TrimSpace(string(body))) == 0
+	switch {
+	case bodyParam == nil && !empty:
+		report("the operation does not take a body")
+	case bodyParam != nil && empty:
+		if bodyParam.Required {
+			report("missing required body")
+		}
+	case bodyParam != nil:
+		var document interface{}
+		if err := json.Unmarshal(body, &document); err != nil {
+			report("body is not valid JSON: %v", err)
+			break
+		}
+		for _, err := range s.validateSchema(bodyParam.Schema, document, "body") {
+			report("%v", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// matchPath returns the path template of the specification matching a path, and the values of its parameters.
+// Templates without parameters win over templates with parameters, like /groups/{groupId}/users over /groups/{groupId}/{x}.
+func (s *Spec) matchPath(path string) (string, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	bestTemplate, bestParams, bestScore := "", map[string]string(nil), -1
+	for template := range s.Paths {
+		templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+		if len(templateSegments) != len(segments) {
+			continue
+		}
+
+		params := map[string]string{}
+		score := 0
+		for i, segment := range templateSegments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				value, err := url.PathUnescape(segments[i])
+				if err != nil {
+					value = segments[i]
+				}
+				params[strings.Trim(segment, "{}")] = value
+				continue
+			}
+			if segment != segments[i] {
+				score = -1
+				break
+			}
+			score++
+		}
+
+		if score > bestScore {
+			bestTemplate, bestParams, bestScore = template, params, score
+		}
+	}
+
+	return bestTemplate, bestParams
+}
+
+// validateValue checks the value of a path or query parameter.
+func (p *Parameter) validateValue(value string) error {
+	if value == "" {
+		if p.Required {
+			return fmt.Errorf("is required")
+		}
+		return nil
+	}
+
+	switch p.Type {
+	case "integer":
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		if p.Minimum != nil && float64(number) < *p.Minimum {
+			return fmt.Errorf("%d is lower than %v", number, *p.Minimum)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+	case "string":
+		if p.Format == "uuid" && !uuidPattern.MatchString(value) {
+			return fmt.Errorf("%q is not a uuid", value)
+		}
+		if len(p.Enum) > 0 && !contains(p.Enum, value) {
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(p.Enum, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateSchema checks a decoded JSON value against a schema. Properties missing from the schema are
+// violations, so misspelled or misplaced properties are caught as well as missing ones.
+func (s *Spec) validateSchema(schema *Schema, value interface{}, at string) []error {
+	schema, err := s.resolve(schema)
+	if err != nil {
+		return []error{fmt.Errorf("%s: %v", at, err)}
+	}
+
+	var errs []error
+	switch schema.Type {
+	case "object", "":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []error{fmt.Errorf("%s: expected an object, got %s", at, describe(value))}
+		}
+		for _, name := range schema.Required {
+			if item, present := object[name]; !present || item == nil {
+				errs = append(errs, fmt.Errorf("%s: missing required property %s", at, name))
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, known := schema.Properties[name]
+			switch {
+			case !known:
+				errs = append(errs, fmt.Errorf("%s: unknown property %s", at, name))
+			case object[name] == nil:
+				// Optional properties may be null, required ones were reported above.
+			default:
+				errs = append(errs, s.validateSchema(property, object[name], at+"."+name)...)
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return []error{fmt.Errorf("%s: expected an array, got %s", at, describe(value))}
+		}
+		for i, item := range array {
+			errs = append(errs, s.validateSchema(schema.Items, item, fmt.Sprintf("%s[%d]", at, i))...)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return []error{fmt.Errorf("%s: expected a string, got %s", at, describe(value))}
+		}
+		if len(schema.Enum) > 0 && !contains(schema.Enum, text) {
+			errs = append(errs, fmt.Errorf("%s: %q is not one of %s", at, text, strings.Join(schema.Enum, ", ")))
+		}
+		if schema.Format == "uuid" && !uuidPattern.MatchString(text) {
+			errs = append(errs, fmt.Errorf("%s: %q is not a uuid", at, text))
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return []error{fmt.Errorf("%s: expected an integer, got %s", at, describe(value))}
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return []error{fmt.Errorf("%s: expected a number, got %s", at, describe(value))}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []error{fmt.Errorf("%s: expected a boolean, got %s", at, describe(value))}
+		}
+	}
+
+	return errs
+}
+
+// resolve follows the $ref of a schema.
+func (s *Spec) resolve(schema *Schema) (*Schema, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("no schema")
+	}
+	if schema.Ref == "" {
+		return schema, nil
+	}
+
+	definition, ok := s.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+	if !ok {
+		return nil, fmt.Errorf("unknown definition %s", schema.Ref)
+	}
+	return definition, nil
+}
+
+// describe returns the JSON type of a decoded value, for error messages.
+func describe(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return fmt.Sprintf("the string %q", value)
+	case float64:
+		return fmt.Sprintf("the number %v", value)
+	case bool:
+		return fmt.Sprintf("the boolean %v", value)
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// contains tells whether values contains value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testGroupId = "f089354e-8366-4e18-aea3-4cb4a3a50b48"
+
+// TestValidateRequest_Valid is a unit test function that tests requests matching the specification are accepted.
+func TestValidateRequest_Valid(t *testing.T) {
+	spec, err := Load()
+	assert.NoError(t, err)
+
+	assert.NoError(t, spec.ValidateRequest("GET", "/v1.0/myorg/groups", url.Values{"$filter": {"name eq 'x'"}, "$top": {"10"}}, nil))
+	assert.NoError(t, spec.ValidateRequest("POST", "/v1.0/myorg/groups", url.Values{"workspaceV2": {"True"}}, []byte(`{"name":"TF_WORKSPACE"}`)))
+	assert.NoError(t, spec.ValidateRequest("PATCH", "/v1.0/myorg/groups/"+testGroupId, nil, []byte(`{"defaultDatasetStorageFormat":"Large"}`)))
+	assert.NoError(t, spec.ValidateRequest("DELETE", "/v1.0/myorg/groups/"+testGroupId+"/users/john.doe%40example.com", nil, nil))
+	assert.NoError(t, spec.ValidateRequest("POST", "/v1.0/myorg/groups/"+testGroupId+"/users", nil,
+		[]byte(`{"emailAddress":"john.doe@example.com","groupUserAccessRight":"Viewer","principalType":"User"}`)))
+	assert.NoError(t, spec.ValidateRequest("POST", "/v1.0/myorg/pipelines/"+testGroupId+"/stages/2/assignWorkspace", nil,
+		[]byte(`{"workspaceId":"`+testGroupId+`"}`)))
+}
+
+// TestValidateRequest_Invalid is a unit test function that tests the violations reported for malformed requests.
+func TestValidateRequest_Invalid(t *testing.T) {
+	spec, err := Load()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		query  url.Values
+		body   string
+		errMsg string
+	}{
+		{"unknown path", "GET", "/v1.0/myorg/reports", nil, "", "no path of the specification matches"},
+		{"outside base path", "GET", "/v2.0/groups", nil, "", "outside of /v1.0/myorg"},
+		{"method not allowed", "PUT", "/v1.0/myorg/groups/" + testGroupId, nil, "", "method is not allowed"},
+		{"invalid id", "GET", "/v1.0/myorg/groups/not-an-id", nil, "", `path parameter groupId: "not-an-id" is not a uuid`},
+		{"unknown query parameter", "GET", "/v1.0/myorg/groups", url.Values{"$orderby": {"name"}}, "", "unknown query parameter $orderby"},
+		{"invalid query parameter", "GET", "/v1.0/myorg/groups", url.Values{"$top": {"0"}}, "", "query parameter $top: 0 is lower than 1"},
+		{"invalid enum query parameter", "GET", "/v1.0/myorg/pipelines", url.Values{"$expand": {"users"}}, "", `"users" is not one of stages`},
+		{"missing body", "POST", "/v1.0/myorg/groups", nil, "", "missing required body"},
+		{"unexpected body", "DELETE", "/v1.0/myorg/groups/" + testGroupId, nil, `{}`, "does not take a body"},
+		{"body is not an object", "PATCH", "/v1.0/myorg/groups/" + testGroupId, nil, `"Large"`, `body: expected an object, got the string "Large"`},
+		{"missing required property", "POST", "/v1.0/myorg/groups", nil, `{}`, "body: missing required property name"},
+		{"unknown property", "POST", "/v1.0/myorg/groups", nil, `{"name":"x","description":"y"}`, "body: unknown property description"},
+		{"invalid enum property", "POST", "/v1.0/myorg/groups/" + testGroupId + "/users", nil,
+			`{"identifier":"x","groupUserAccessRight":"Owner","principalType":"App"}`, `body.groupUserAccessRight: "Owner" is not one of`},
+		{"invalid property type", "PATCH", "/v1.0/myorg/pipelines/" + testGroupId, nil, `{"displayName":1}`, "body.displayName: expected a string, got the number 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := spec.ValidateRequest(tt.method, tt.path, tt.query, []byte(tt.body))
+
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+// TestValidateRequest_Fabric is a unit test function that tests requests are checked against the Fabric specification.
+func TestValidateRequest_Fabric(t *testing.T) {
+	spec, err := LoadFabric()
+	assert.NoError(t, err)
+
+	assert.NoError(t, spec.ValidateRequest("GET", "/v1/workspaces/"+testGroupId, nil, nil))
+	assert.NoError(t, spec.ValidateRequest("PATCH", "/v1/workspaces/"+testGroupId, nil, []byte(`{"description":"Sales reports"}`)))
+
+	err = spec.ValidateRequest("PATCH", "/v1/workspaces/"+testGroupId, nil, []byte(`{"defaultDatasetStorageFormat":"Large"}`))
+	assert.ErrorContains(t, err, "body: unknown property defaultDatasetStorageFormat")
+
+	err = spec.ValidateRequest("GET", "/v1.0/myorg/groups/"+testGroupId, nil, nil)
+	assert.ErrorContains(t, err, "outside of /v1")
+}
+
+// TestValidateRequest_Graph is a unit test function that tests requests are checked against the Microsoft Graph specification.
+func TestValidateRequest_Graph(t *testing.T) {
+	spec, err := LoadGraph()
+	assert.NoError(t, err)
+
+	assert.NoError(t, spec.ValidateRequest("GET", "/v1.0/users/john.doe@example.com", url.Values{"$select": {"id,userPrincipalName"}}, nil))
+	assert.NoError(t, spec.ValidateRequest("GET", "/v1.0/groups", url.Values{"$filter": {"displayName eq 'Sales'"}}, nil))
+	assert.NoError(t, spec.ValidateRequest("GET", "/v1.0/servicePrincipals", url.Values{"$filter": {"appId eq '" + testGroupId + "'"}}, nil))
+
+	err = spec.ValidateRequest("GET", "/v1.0/groups", nil, nil)
+	assert.ErrorContains(t, err, "missing required query parameter $filter")
+
+	err = spec.ValidateRequest("GET", "/v1/workspaces/"+testGroupId, nil, nil)
+	assert.ErrorContains(t, err, "outside of /v1.0")
+}
@@ -0,0 +1,289 @@
+// Package recorder records Power BI REST API exchanges into cassette files and replays them.
+//
+// A Recorder is an http.RoundTripper meant to be plugged into the client with
+// Client.RestyClient.SetTransport. In record mode it forwards requests to the real service and
+// keeps the exchanges, scrubbed from tokens and personal data, until Stop writes them to the
+// cassette. In replay mode it answers from the cassette and never touches the network, which is
+// what the tests do by default.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordEnvVar is the environment variable that switches the cassette tests to record mode.
+const RecordEnvVar string = "POWERBI_RECORD"
+
+// Mode tells whether a Recorder records or replays exchanges.
+type Mode int
+
+const (
+	ModeReplay Mode = iota // Answer from the cassette, without network access
+	ModeRecord             // Call the real service and overwrite the cassette
+)
+
+// ModeFromEnv returns ModeRecord when the POWERBI_RECORD environment variable is set to a true value, ModeReplay otherwise.
+func ModeFromEnv() Mode {
+	switch strings.ToLower(os.Getenv(RecordEnvVar)) {
+	case "1", "true", "yes":
+		return ModeRecord
+	default:
+		return ModeReplay
+	}
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	RecordedAt   time.Time     `json:"recordedAt"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response the service gave to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. The host is not kept, so cassettes can be replayed against any base URL.
+type Request struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+}
+
+// keptHeaders are the response headers stored in cassettes, the others may leak tenant details.
+var keptHeaders = []string{"Content-Type", "Location", "Retry-After"}
+
+// Recorder records or replays the exchanges of one cassette.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+	scrubber  *Scrubber
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// Option customizes a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to reach the service in record mode. It defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithReplacement replaces every occurrence of a sensitive value, such as the email of the test user,
+// with a placeholder before it is written to the cassette. Tests replaying the cassette use the placeholder.
+// An empty value only protects the placeholder, so the option can be fed from unset environment variables.
+func WithReplacement(value string, placeholder string) Option {
+	return func(r *Recorder) {
+		r.scrubber.Replace(value, placeholder)
+	}
+}
+
+// New creates a Recorder for the cassette at path.
+// In replay mode the cassette must exist, in record mode it is created or overwritten by Stop.
+func New(path string, mode Mode, options ...Option) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+		scrubber:  NewScrubber(),
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	if mode == ModeRecord {
+		r.cassette.RecordedAt = time.Now().UTC().Truncate(time.Second)
+		return r, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette %s, record it with %s=1: %v", path, RecordEnvVar, err)
+	}
+
+	if err := json.Unmarshal(content, &r.cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %v", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// Mode returns the mode of the Recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %v", err)
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, body)
+	}
+
+	return r.replay(req, body)
+}
+
+// Stop ends the recording. In record mode it writes the cassette, in replay mode it fails when
+// recorded interactions were not replayed, which means the code under test changed its calls.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		for i, used := range r.used {
+			if !used {
+				request := r.cassette.Interactions[i].Request
+				return fmt.Errorf("cassette %s: interaction %d (%s %s) was not replayed", r.path, i, request.Method, request.URL)
+			}
+		}
+		return nil
+	}
+
+	content, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette %s: %v", r.path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the cassette directory: %v", err)
+	}
+
+	return os.WriteFile(r.path, append(content, '\n'), 0o600)
+}
+
+// record forwards the request to the service and keeps the scrubbed exchange.
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.scrubber.String(req.URL.RequestURI()),
+			Body:   r.scrubber.JSON(body),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    map[string]string{},
+			Body:       r.scrubber.JSON(respBody),
+		},
+	}
+
+	for _, header := range keptHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			interaction.Response.Headers[header] = value
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay answers with the first recorded interaction matching the request that was not replayed yet.
+// Requests are scrubbed the same way as during the recording before they are compared.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	url := r.scrubber.String(req.URL.RequestURI())
+	scrubbedBody := r.scrubber.JSON(body)
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != url {
+			continue
+		}
+		if !sameJSON(interaction.Request.Body, scrubbedBody) {
+			continue
+		}
+
+		r.used[i] = true
+
+		resp := &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}
+		for name, value := range interaction.Response.Headers {
+			resp.Header.Set(name, value)
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s: no recorded interaction for %s %s %s", r.path, req.Method, url, scrubbedBody)
+}
+
+// readAll reads and closes a body that may be nil.
+func readAll(body io.ReadCloser) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// sameJSON compares two JSON documents, ignoring formatting and key order.
+func sameJSON(a json.RawMessage, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+
+	ca, _ := json.Marshal(va)
+	cb, _ := json.Marshal(vb)
+
+	return bytes.Equal(ca, cb)
+}
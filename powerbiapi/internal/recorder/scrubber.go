@@ -0,0 +1,126 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactedEmail replaces the email addresses found in the exchanges.
+const RedactedEmail string = "redacted@example.com"
+
+// RedactedValue replaces tokens and principal names found in the exchanges.
+const RedactedValue string = "REDACTED"
+
+var (
+	// emailPattern matches email addresses and user principal names.
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-']+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+	// jwtPattern matches JSON web tokens, such as bearer tokens echoed by the service.
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+)
+
+// secretKeys are JSON properties whose value is always redacted.
+var secretKeys = map[string]bool{
+	"access_token":  true,
+	"accessToken":   true,
+	"refresh_token": true,
+	"token":         true,
+}
+
+// Scrubber removes tokens and personal data from recorded exchanges.
+// Explicit replacements are applied first, then email addresses and tokens are redacted,
+// as well as the display names of principals (objects carrying a principalType).
+type Scrubber struct {
+	replacements map[string]string
+	placeholders map[string]bool
+}
+
+// NewScrubber returns a Scrubber without explicit replacements.
+func NewScrubber() *Scrubber {
+	return &Scrubber{replacements: map[string]string{}, placeholders: map[string]bool{}}
+}
+
+// Replace registers an explicit replacement. The placeholder itself is never redacted,
+// even when the value is empty, so replayed requests carrying it match the cassette.
+func (s *Scrubber) Replace(value string, placeholder string) {
+	s.placeholders[placeholder] = true
+	if value != "" {
+		s.replacements[value] = placeholder
+	}
+}
+
+// String scrubs a piece of text, such as a request URL.
+func (s *Scrubber) String(text string) string {
+	// Replace the longest values first, so a value containing another one is replaced as a whole.
+	values := make([]string, 0, len(s.replacements))
+	for value := range s.replacements {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, s.replacements[value])
+	}
+
+	text = jwtPattern.ReplaceAllString(text, RedactedValue)
+
+	return emailPattern.ReplaceAllStringFunc(text, func(email string) string {
+		if s.placeholders[email] {
+			return email
+		}
+		return RedactedEmail
+	})
+}
+
+// JSON scrubs a JSON body. Bodies that are not JSON are scrubbed as text and stored as a JSON string.
+func (s *Scrubber) JSON(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		text, _ := json.Marshal(s.String(string(body)))
+		return text
+	}
+
+	scrubbed, err := json.Marshal(s.value(document))
+	if err != nil {
+		return nil
+	}
+	return scrubbed
+}
+
+// value scrubs a decoded JSON value.
+func (s *Scrubber) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return s.String(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = s.value(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		_, isPrincipal := v["principalType"]
+		for key, item := range v {
+			switch {
+			case secretKeys[key]:
+				v[key] = RedactedValue
+			case isPrincipal && key == "displayName":
+				v[key] = RedactedValue
+			default:
+				v[key] = s.value(item)
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
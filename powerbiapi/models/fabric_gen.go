@@ -0,0 +1,44 @@
+// Code generated by modelgen from the Fabric REST API specification. DO NOT EDIT.
+
+package models
+
+// Item is a Fabric item, such as a datamart or a semantic model.
+type Item struct {
+	Description string `json:"description"` // The item description.
+	DisplayName string `json:"displayName"` // The item display name.
+	Id          string `json:"id"`          // The item ID.
+	Type        string `json:"type"`        // The item type, such as Datamart, Report or SemanticModel.
+	WorkspaceId string `json:"workspaceId"` // The workspace ID.
+}
+
+// Items is a page of Fabric items.
+type Items struct {
+	ContinuationToken string `json:"continuationToken"` // The token for the next result set batch. If there are no more records, it's removed from the response.
+	ContinuationUri   string `json:"continuationUri"`   // The URI of the next result set batch. If there are no more records, it's removed from the response.
+	Value             []Item `json:"value"`             // A list of items.
+}
+
+// UpdateWorkspaceRequest is a request to update a Fabric workspace.
+type UpdateWorkspaceRequest struct {
+	Description string `json:"description"` // The workspace description. The description cannot contain more than 4000 characters.
+	DisplayName string `json:"displayName"` // The workspace display name.
+}
+
+// Workspace is a Fabric workspace.
+type Workspace struct {
+	CapacityId  string `json:"capacityId"`  // The ID of the capacity the workspace is assigned to.
+	Description string `json:"description"` // The workspace description.
+	DisplayName string `json:"displayName"` // The workspace display name.
+	Id          string `json:"id"`          // The workspace ID.
+	Type        string `json:"type"`        // The workspace type, Workspace, Personal or AdminWorkspace.
+}
+
+// WorkspaceInfo is a Fabric workspace with its capacity assignment progress.
+type WorkspaceInfo struct {
+	CapacityAssignmentProgress string `json:"capacityAssignmentProgress"` // The status of the last capacity assignment of the workspace.
+	CapacityId                 string `json:"capacityId"`                 // The ID of the capacity the workspace is assigned to.
+	Description                string `json:"description"`                // The workspace description.
+	DisplayName                string `json:"displayName"`                // The workspace display name.
+	Id                         string `json:"id"`                         // The workspace ID.
+	Type                       string `json:"type"`                       // The workspace type, Workspace, Personal or AdminWorkspace.
+}
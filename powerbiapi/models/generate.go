@@ -0,0 +1,10 @@
+package models
+
+// The model types and enums are generated from the snapshots of the Power BI and Fabric REST API specifications.
+// To add a model, add its definition to ../internal/openapi/swagger.json, or fabric.json for the Fabric API
+// and graph.json for Microsoft Graph, and run "go generate" in this directory.
+// Helpers such as the Validate methods stay in hand-written files next to the generated one.
+
+//go:generate go run ../../tools/modelgen -spec ../internal/openapi/swagger.json -out models_gen.go
+//go:generate go run ../../tools/modelgen -spec ../internal/openapi/fabric.json -out fabric_gen.go
+//go:generate go run ../../tools/modelgen -spec ../internal/openapi/graph.json -out graph_gen.go
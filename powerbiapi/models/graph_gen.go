@@ -0,0 +1,35 @@
+// Code generated by modelgen from the Microsoft Graph REST API specification. DO NOT EDIT.
+
+package models
+
+// GraphGroup is a Microsoft Entra group.
+type GraphGroup struct {
+	DisplayName string `json:"displayName"` // The display name of the group.
+	Id          string `json:"id"`          // The object ID of the group.
+	Mail        string `json:"mail"`        // The email address of the group.
+}
+
+// GraphGroups is a list of Microsoft Entra groups.
+type GraphGroups struct {
+	Value []GraphGroup `json:"value"` // The groups.
+}
+
+// GraphServicePrincipal is a Microsoft Entra service principal, the instance of an application in the tenant.
+type GraphServicePrincipal struct {
+	AppId       string `json:"appId"`       // The application (client) ID of the application.
+	DisplayName string `json:"displayName"` // The display name of the service principal.
+	Id          string `json:"id"`          // The object ID of the service principal.
+}
+
+// GraphServicePrincipals is a list of Microsoft Entra service principals.
+type GraphServicePrincipals struct {
+	Value []GraphServicePrincipal `json:"value"` // The service principals.
+}
+
+// GraphUser is a Microsoft Entra user.
+type GraphUser struct {
+	DisplayName       string `json:"displayName"`       // The display name of the user.
+	Id                string `json:"id"`                // The object ID of the user.
+	Mail              string `json:"mail"`              // The email address of the user.
+	UserPrincipalName string `json:"userPrincipalName"` // The user principal name (UPN) of the user.
+}
@@ -0,0 +1,197 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// The generated GroupUser contains all the available properties for a group user.
+// But, when assigning a user to a group, the API only accepts an object with the required properties.
+// Adding the other properties, even null or empty, to the object will result in an error.
+// So, we create separate structs for the different types of group users.
+
+// GroupUserPrincipal is the body of a request assigning a principal to a workspace (group).
+// It is implemented by GroupUserEmail, GroupUserGroup, GroupUserApp and GroupUserProfile, one per kind of principal.
+type GroupUserPrincipal interface {
+	groupUserPrincipal()
+}
+
+// GroupUserEmail is a structure with the required properties to assign a user to a workspace (group).
+type GroupUserEmail struct {
+	EmailAddress         string               `json:"emailAddress"`         // Email address of the user.
+	GroupUserAccessRight GroupUserAccessRight `json:"groupUserAccessRight"` // The access right (permission level) that a user has on the workspace.
+	PrincipalType        PrincipalType        `json:"principalType"`        // The principal type, always User.
+}
+
+// GroupUserGroup is a structure with the required properties to assign a security group to a workspace (group).
+type GroupUserGroup struct {
+	Identifier           string               `json:"identifier"`           // Object ID of the group.
+	GroupUserAccessRight GroupUserAccessRight `json:"groupUserAccessRight"` // The access right (permission level) that a user has on the workspace.
+	PrincipalType        PrincipalType        `json:"principalType"`        // The principal type, always Group.
+}
+
+// GroupUserApp is a structure with the required properties to assign a service principal to a workspace (group).
+type GroupUserApp struct {
+	Identifier           string               `json:"identifier"`           // Object ID of the service principal.
+	GroupUserAccessRight GroupUserAccessRight `json:"groupUserAccessRight"` // The access right (permission level) that a user has on the workspace.
+	PrincipalType        PrincipalType        `json:"principalType"`        // The principal type, always App.
+}
+
+// GroupUserProfile is a structure with the required properties to assign a service principal profile to a workspace (group).
+// Profiles are only relevant for Power BI Embedded multi-tenancy solutions.
+type GroupUserProfile struct {
+	Identifier           string                           `json:"identifier"`           // Object ID of the service principal owning the profile.
+	GroupUserAccessRight GroupUserAccessRight             `json:"groupUserAccessRight"` // The access right (permission level) that a user has on the workspace.
+	PrincipalType        PrincipalType                    `json:"principalType"`        // The principal type, always App.
+	Profile              ServicePrincipalProfileReference `json:"profile"`              // The service principal profile.
+}
+
+// ServicePrincipalProfileReference identifies a service principal profile in a request.
+// Unlike ServicePrincipalProfile, it has no display name, which is not accepted.
+type ServicePrincipalProfileReference struct {
+	Id string `json:"id"` // The service principal profile ID.
+}
+
+func (GroupUserEmail) groupUserPrincipal()   {}
+func (GroupUserGroup) groupUserPrincipal()   {}
+func (GroupUserApp) groupUserPrincipal()     {}
+func (GroupUserProfile) groupUserPrincipal() {}
+
+// GrantableGroupUserAccessRights are the access rights which can be granted on a workspace.
+// None is left out, a principal loses its access when it is removed from the workspace.
+var GrantableGroupUserAccessRights = []GroupUserAccessRight{
+	GroupUserAccessRightAdmin,
+	GroupUserAccessRightMember,
+	GroupUserAccessRightContributor,
+	GroupUserAccessRightViewer,
+}
+
+// AssignablePrincipalTypes are the principal types which can be assigned to a workspace.
+// None, which stands for the whole organization, is left out.
+var AssignablePrincipalTypes = []PrincipalType{
+	PrincipalTypeUser,
+	PrincipalTypeGroup,
+	PrincipalTypeApp,
+}
+
+// GroupUserError is returned when a group user cannot be assigned to a workspace, before any request is sent.
+// Property is the JSON name of the invalid property of the GroupUser.
+type GroupUserError struct {
+	Property string
+	Reason   string
+}
+
+func (e *GroupUserError) Error() string {
+	return fmt.Sprintf("invalid group user %s: %s", e.Property, e.Reason)
+}
+
+// Validate checks that the access right is one of GrantableGroupUserAccessRights.
+func (r GroupUserAccessRight) Validate() error {
+	if slices.Contains(GrantableGroupUserAccessRights, r) {
+		return nil
+	}
+
+	switch r {
+	case "":
+		return &GroupUserError{Property: "groupUserAccessRight", Reason: "the access right is required"}
+	case GroupUserAccessRightNone:
+		return &GroupUserError{Property: "groupUserAccessRight", Reason: "None cannot be granted, remove the principal from the workspace instead"}
+	default:
+		return &GroupUserError{
+			Property: "groupUserAccessRight",
+			Reason:   fmt.Sprintf("%q is not one of %s", r, joinValues(GrantableGroupUserAccessRights)),
+		}
+	}
+}
+
+// Principal checks the properties identifying the principal of the GroupUser, whatever its access right,
+// and returns the struct for the API call. The access right of the returned struct is the one of the GroupUser.
+func (g *GroupUser) Principal() (GroupUserPrincipal, error) {
+	hasProfile := g.Profile.Id != "" || g.Profile.DisplayName != ""
+
+	switch g.PrincipalType {
+	case PrincipalTypeUser:
+		switch {
+		case g.EmailAddress == "":
+			return nil, &GroupUserError{Property: "emailAddress", Reason: "a user is given by its email address, which is required"}
+		case g.Identifier != "":
+			return nil, &GroupUserError{Property: "identifier", Reason: "a user is given by its email address, the identifier must not be set"}
+		case hasProfile:
+			return nil, &GroupUserError{Property: "profile", Reason: "a profile is only valid for apps"}
+		}
+		return GroupUserEmail{
+			EmailAddress:         g.EmailAddress,
+			GroupUserAccessRight: g.GroupUserAccessRight,
+			PrincipalType:        g.PrincipalType,
+		}, nil
+
+	case PrincipalTypeGroup, PrincipalTypeApp:
+		switch {
+		case g.Identifier == "":
+			return nil, &GroupUserError{Property: "identifier", Reason: "a group or an app is given by its object ID, which is required"}
+		case g.EmailAddress != "":
+			return nil, &GroupUserError{Property: "emailAddress", Reason: "an email address is only valid for users"}
+		case hasProfile && g.PrincipalType != PrincipalTypeApp:
+			return nil, &GroupUserError{Property: "profile", Reason: "a profile is only valid for apps"}
+		case hasProfile && g.Profile.Id == "":
+			return nil, &GroupUserError{Property: "profile", Reason: "a profile is given by its ID, which is required"}
+		}
+
+		if hasProfile {
+			return GroupUserProfile{
+				Identifier:           g.Identifier,
+				GroupUserAccessRight: g.GroupUserAccessRight,
+				PrincipalType:        g.PrincipalType,
+				Profile:              ServicePrincipalProfileReference{Id: g.Profile.Id},
+			}, nil
+		}
+		if g.PrincipalType == PrincipalTypeApp {
+			return GroupUserApp{
+				Identifier:           g.Identifier,
+				GroupUserAccessRight: g.GroupUserAccessRight,
+				PrincipalType:        g.PrincipalType,
+			}, nil
+		}
+		return GroupUserGroup{
+			Identifier:           g.Identifier,
+			GroupUserAccessRight: g.GroupUserAccessRight,
+			PrincipalType:        g.PrincipalType,
+		}, nil
+
+	case "":
+		return nil, &GroupUserError{Property: "principalType", Reason: "the principal type is required"}
+	case PrincipalTypeNone:
+		return nil, &GroupUserError{Property: "principalType", Reason: "None stands for the whole organization, which cannot be assigned to a workspace"}
+	default:
+		return nil, &GroupUserError{
+			Property: "principalType",
+			Reason:   fmt.Sprintf("%q is not one of %s", g.PrincipalType, joinValues(AssignablePrincipalTypes)),
+		}
+	}
+}
+
+// Validate checks the properties of the GroupUser and returns the struct for the API call.
+// The returned error is a *GroupUserError naming the invalid property.
+func (g *GroupUser) Validate() (GroupUserPrincipal, error) {
+	principal, err := g.Principal()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.GroupUserAccessRight.Validate(); err != nil {
+		return nil, err
+	}
+
+	return principal, nil
+}
+
+// joinValues returns the values of an enum as a comma-separated list.
+func joinValues[T ~string](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return strings.Join(s, ", ")
+}
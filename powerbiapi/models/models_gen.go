@@ -0,0 +1,285 @@
+// Code generated by modelgen from the Power BI REST API specification. DO NOT EDIT.
+
+package models
+
+import "time"
+
+// AssignToCapacityRequest is a request to assign a workspace to a capacity.
+type AssignToCapacityRequest struct {
+	CapacityId string `json:"capacityId"` // The capacity ID. To unassign from a capacity, use an empty GUID (00000000-0000-0000-0000-000000000000).
+}
+
+// AssignToDataflowStorageRequest is a request to assign a workspace to a Power BI dataflow storage account.
+type AssignToDataflowStorageRequest struct {
+	DataflowStorageId string `json:"dataflowStorageId"` // The Power BI dataflow storage account ID. To unassign the specified workspace from a Power BI dataflow storage account, use an empty GUID (00000000-0000-0000-0000-000000000000).
+}
+
+// AssignWorkspaceRequest is a request to assign a workspace to a deployment pipeline stage.
+type AssignWorkspaceRequest struct {
+	WorkspaceId string `json:"workspaceId"` // The workspace ID.
+}
+
+// AzureResource is a response detailing a user-owned Azure resource such as a Log Analytics workspace.
+type AzureResource struct {
+	Id             string `json:"id"`             // An identifier for the resource within Power BI.
+	ResourceGroup  string `json:"resourceGroup"`  // The resource group within the subscription where the resource resides.
+	ResourceName   string `json:"resourceName"`   // The name of the resource.
+	SubscriptionId string `json:"subscriptionId"` // The Azure subscription where the resource resides.
+}
+
+// Dashboard is a Power BI dashboard.
+type Dashboard struct {
+	DisplayName string `json:"displayName"` // The display name of the dashboard.
+	Id          string `json:"id"`          // The dashboard ID.
+	IsReadOnly  bool   `json:"isReadOnly"`  // Whether the dashboard is read-only.
+	WebUrl      string `json:"webUrl"`      // The web URL of the dashboard.
+}
+
+// Dashboards is the OData response wrapper for a Power BI dashboard collection.
+type Dashboards struct {
+	ODataContext string      `json:"@odata.context"` // The OData context.
+	Value        []Dashboard `json:"value"`          // The list of dashboards.
+}
+
+// Dataflow is a Power BI dataflow.
+type Dataflow struct {
+	ConfiguredBy string `json:"configuredBy"` // The dataflow owner.
+	Description  string `json:"description"`  // The dataflow description.
+	Name         string `json:"name"`         // The dataflow name.
+	ObjectId     string `json:"objectId"`     // The dataflow ID.
+}
+
+// DataflowStorageAccount is a Power BI dataflow storage account.
+type DataflowStorageAccount struct {
+	Id        string `json:"id"`        // The Power BI dataflow storage account ID.
+	IsEnabled bool   `json:"isEnabled"` // Whether the workspaces can be assigned to the Power BI dataflow storage account.
+	Name      string `json:"name"`      // The Power BI dataflow storage account name.
+}
+
+// DataflowStorageAccounts is the OData response wrapper for a list of Power BI dataflow storage accounts.
+type DataflowStorageAccounts struct {
+	ODataContext string                   `json:"@odata.context"` // The OData context.
+	Value        []DataflowStorageAccount `json:"value"`          // The list of Power BI dataflow storage accounts.
+}
+
+// Dataflows is the OData response wrapper for a Power BI dataflow collection.
+type Dataflows struct {
+	ODataContext string     `json:"@odata.context"` // The OData context.
+	Value        []Dataflow `json:"value"`          // The list of dataflows.
+}
+
+// Dataset is a Power BI dataset.
+type Dataset struct {
+	ConfiguredBy  string `json:"configuredBy"`  // The dataset owner.
+	Id            string `json:"id"`            // The dataset ID.
+	IsRefreshable bool   `json:"isRefreshable"` // Whether the dataset can be refreshed.
+	Name          string `json:"name"`          // The dataset name.
+	WebUrl        string `json:"webUrl"`        // The web URL of the dataset.
+}
+
+// Datasets is the OData response wrapper for a Power BI dataset collection.
+type Datasets struct {
+	ODataContext string    `json:"@odata.context"` // The OData context.
+	Value        []Dataset `json:"value"`          // The list of datasets.
+}
+
+// Group is a Power BI group (workspace).
+type Group struct {
+	CapacityId                  string                      `json:"capacityId"`                  // The capacity ID.
+	DataflowStorageId           string                      `json:"dataflowStorageId"`           // The Power BI dataflow storage account ID.
+	DefaultDatasetStorageFormat DefaultDatasetStorageFormat `json:"defaultDatasetStorageFormat"` // The default dataset storage format in the workspace. Returned only when isOnDedicatedCapacity is true.
+	Description                 string                      `json:"description"`                 // The group description. Available only for admin API calls.
+	Id                          string                      `json:"id"`                          // The workspace ID.
+	IsOnDedicatedCapacity       bool                        `json:"isOnDedicatedCapacity"`       // Whether the group is assigned to a dedicated capacity.
+	IsReadOnly                  bool                        `json:"isReadOnly"`                  // Whether the group is read-only.
+	LogAnalyticsWorkspace       AzureResource               `json:"logAnalyticsWorkspace"`       // The Log Analytics workspace assigned to the group. This is returned only when retrieving a single group.
+	Name                        string                      `json:"name"`                        // The group name.
+	State                       GroupState                  `json:"state"`                       // The group state. Available only for admin API calls.
+	Type                        GroupType                   `json:"type"`                        // The type of group being returned.
+}
+
+// GroupCreationRequest is a Power BI request to create a group (workspace).
+type GroupCreationRequest struct {
+	Name string `json:"name"` // The name of the newly created group.
+}
+
+// GroupRestoreRequest is a Power BI request to restore a deleted group (workspace).
+type GroupRestoreRequest struct {
+	EmailAddress string `json:"emailAddress"` // The email address of the owner of the group to be restored.
+	Name         string `json:"name"`         // The new name of the group to be restored. The name of the group is kept when it is empty.
+}
+
+// GroupUser is a Power BI user with access to the workspace.
+type GroupUser struct {
+	DisplayName          string                  `json:"displayName"`          // The display name of the principal.
+	EmailAddress         string                  `json:"emailAddress"`         // The email address of the user.
+	GraphId              string                  `json:"graphId"`              // The identifier of the principal in Microsoft Graph. Only available for admin APIs.
+	GroupUserAccessRight GroupUserAccessRight    `json:"groupUserAccessRight"` // The access right (permission level) that a user has on the workspace.
+	Identifier           string                  `json:"identifier"`           // The identifier of the principal.
+	PrincipalType        PrincipalType           `json:"principalType"`        // The principal type.
+	Profile              ServicePrincipalProfile `json:"profile"`              // A Power BI service principal profile. Only relevant for Power BI Embedded multi-tenancy solution.
+	UserType             string                  `json:"userType"`             // The type of the user.
+}
+
+// GroupUsers is the OData response wrapper for a list of Power BI users with access to a workspace.
+type GroupUsers struct {
+	ODataContext string      `json:"@odata.context"` // The OData context.
+	ODataCount   int         `json:"@odata.count"`   // The OData count.
+	Value        []GroupUser `json:"value"`          // The list of users with access to a workspace.
+}
+
+// Groups is the OData response wrapper for a Power BI group collection.
+type Groups struct {
+	ODataContext string  `json:"@odata.context"` // The OData context.
+	ODataCount   int     `json:"@odata.count"`   // The OData count.
+	Value        []Group `json:"value"`          // The list of groups.
+}
+
+// Pipeline is a Power BI deployment pipeline.
+type Pipeline struct {
+	Description string          `json:"description"` // The deployment pipeline description.
+	DisplayName string          `json:"displayName"` // The deployment pipeline display name.
+	Id          string          `json:"id"`          // The deployment pipeline ID.
+	Stages      []PipelineStage `json:"stages"`      // The collection of deployment pipeline stages. Only returned when $expand is set to stages in the request.
+	Users       []PipelineUser  `json:"users"`       // The collection of deployment pipeline users. Only returned when $expand is set to users in the request.
+}
+
+// PipelineCreationRequest is a request to create a deployment pipeline.
+type PipelineCreationRequest struct {
+	Description string `json:"description"` // The deployment pipeline description.
+	DisplayName string `json:"displayName"` // The deployment pipeline display name.
+}
+
+// PipelineStage is a Power BI deployment pipeline stage.
+type PipelineStage struct {
+	Order         int    `json:"order"`         // The stage order, starting from zero.
+	WorkspaceId   string `json:"workspaceId"`   // The assigned workspace ID. Only applicable when there's an assigned workspace.
+	WorkspaceName string `json:"workspaceName"` // The assigned workspace name. Only applicable when there's an assigned workspace and the user has access to the workspace.
+}
+
+// PipelineStages is the OData response wrapper for a Power BI deployment pipeline stage collection.
+type PipelineStages struct {
+	ODataContext string          `json:"@odata.context"` // The OData context.
+	Value        []PipelineStage `json:"value"`          // The list of deployment pipeline stages.
+}
+
+// PipelineUser is a Power BI user access right entry for a deployment pipeline.
+type PipelineUser struct {
+	AccessRight   PipelineUserAccessRight `json:"accessRight"`   // The access right that a user has for the deployment pipeline.
+	Identifier    string                  `json:"identifier"`    // For principal type User, provide the UPN. Otherwise provide the object ID of the principal.
+	PrincipalType PrincipalType           `json:"principalType"` // The principal type.
+}
+
+// Pipelines is the OData response wrapper for a collection of Power BI deployment pipelines.
+type Pipelines struct {
+	ODataContext string     `json:"@odata.context"` // The OData context.
+	Value        []Pipeline `json:"value"`          // The collection of deployment pipelines.
+}
+
+// Report is a Power BI report.
+type Report struct {
+	DatasetId  string `json:"datasetId"`  // The ID of the dataset of the report.
+	Id         string `json:"id"`         // The report ID.
+	Name       string `json:"name"`       // The name of the report.
+	ReportType string `json:"reportType"` // The report type, PowerBIReport or PaginatedReport.
+	WebUrl     string `json:"webUrl"`     // The web URL of the report.
+}
+
+// Reports is the OData response wrapper for a Power BI report collection.
+type Reports struct {
+	ODataContext string   `json:"@odata.context"` // The OData context.
+	Value        []Report `json:"value"`          // The list of reports.
+}
+
+// ServicePrincipalProfile is a Power BI service principal profile. Only relevant for Power BI Embedded multi-tenancy solution.
+type ServicePrincipalProfile struct {
+	DisplayName string `json:"displayName"` // The service principal profile name.
+	Id          string `json:"id"`          // The service principal profile ID.
+}
+
+// UpdateGroupRequest is a Power BI request to update a group (workspace).
+type UpdateGroupRequest struct {
+	DefaultDatasetStorageFormat DefaultDatasetStorageFormat `json:"defaultDatasetStorageFormat"` // The default dataset storage format in the group.
+	Name                        string                      `json:"name"`                        // The group name.
+}
+
+// UpdatePipelineRequest is a request to update a deployment pipeline.
+type UpdatePipelineRequest struct {
+	Description string `json:"description"` // The deployment pipeline description.
+	DisplayName string `json:"displayName"` // The deployment pipeline display name.
+}
+
+// WorkspaceCapacityAssignmentStatus is the status of a workspace assignment to capacity operation.
+type WorkspaceCapacityAssignmentStatus struct {
+	ActivityId string           `json:"activityId"` // The activity ID of the actual assignment operation, which can be provided in case of an assignment failure.
+	CapacityId string           `json:"capacityId"` // The capacity ID.
+	EndTime    time.Time        `json:"endTime"`    // The end date and time of the workspace assignment operation.
+	StartTime  time.Time        `json:"startTime"`  // The start date and time of the workspace assignment operation.
+	Status     AssignmentStatus `json:"status"`     // The workspace assignment status.
+}
+
+// AssignmentStatus is the status of a workspace assignment to capacity operation.
+type AssignmentStatus string
+
+const (
+	AssignmentStatusAssignmentFailed      AssignmentStatus = "AssignmentFailed"      // Assignment failed.
+	AssignmentStatusCompletedSuccessfully AssignmentStatus = "CompletedSuccessfully" // Assignment is completed.
+	AssignmentStatusInProgress            AssignmentStatus = "InProgress"            // Assignment is in progress.
+	AssignmentStatusPending               AssignmentStatus = "Pending"               // Assignment isn't started.
+)
+
+// DefaultDatasetStorageFormat is the default dataset storage format in the workspace.
+type DefaultDatasetStorageFormat string
+
+const (
+	DefaultDatasetStorageFormatLarge DefaultDatasetStorageFormat = "Large" // Large dataset storage format.
+	DefaultDatasetStorageFormatSmall DefaultDatasetStorageFormat = "Small" // Small dataset storage format.
+)
+
+// GroupState is the group state. Available only for admin API calls.
+type GroupState string
+
+const (
+	GroupStateActive   GroupState = "Active"   // The workspace is active.
+	GroupStateDeleted  GroupState = "Deleted"  // The workspace is deleted and can be restored by an administrator.
+	GroupStateRemoving GroupState = "Removing" // The workspace is being removed.
+)
+
+// GroupType is the type of group being returned.
+type GroupType string
+
+const (
+	GroupTypeAdminInsights GroupType = "AdminInsights" // The admin monitoring workspace.
+	GroupTypeGroup         GroupType = "Group"         // A classic workspace, backed by a Microsoft 365 group.
+	GroupTypePersonal      GroupType = "Personal"      // A personal workspace of a user, classic flavor.
+	GroupTypePersonalGroup GroupType = "PersonalGroup" // A personal workspace of a user.
+	GroupTypeWorkspace     GroupType = "Workspace"     // A workspace.
+)
+
+// GroupUserAccessRight is the access right (permission level) that a user has on the workspace.
+type GroupUserAccessRight string
+
+const (
+	GroupUserAccessRightAdmin       GroupUserAccessRight = "Admin"       // Grants administrator rights to the workspace.
+	GroupUserAccessRightContributor GroupUserAccessRight = "Contributor" // Grants read and write access to content in the workspace.
+	GroupUserAccessRightMember      GroupUserAccessRight = "Member"      // Grants read, write, and reshare access to content in the workspace.
+	GroupUserAccessRightNone        GroupUserAccessRight = "None"        // Removes permission to the content in the workspace.
+	GroupUserAccessRightViewer      GroupUserAccessRight = "Viewer"      // Grants read-only access to content in the workspace.
+)
+
+// PipelineUserAccessRight is the access right that a user has for the deployment pipeline.
+type PipelineUserAccessRight string
+
+const (
+	PipelineUserAccessRightAdmin PipelineUserAccessRight = "Admin" // Grants administrator rights to the deployment pipeline.
+)
+
+// PrincipalType is the principal type.
+type PrincipalType string
+
+const (
+	PrincipalTypeApp   PrincipalType = "App"   // Service principal type.
+	PrincipalTypeGroup PrincipalType = "Group" // Group principal type.
+	PrincipalTypeNone  PrincipalType = "None"  // No principal type. Use for whole organization level access.
+	PrincipalTypeUser  PrincipalType = "User"  // User principal type.
+)
@@ -0,0 +1,30 @@
+package models
+
+// UpdateGroupAsAdminLogAnalyticsWorkspace represents an admin request to assign a Log Analytics workspace
+// to a Power BI group (workspace). A nil LogAnalyticsWorkspace unassigns it.
+// The request body is a Group, of which only the ID, required by the API, and the Log Analytics workspace are sent.
+// The Log Analytics workspace is identified by its subscription, resource group and name: its id is also required
+// by the API, but it is assigned by the service, so it is sent empty and ignored.
+type UpdateGroupAsAdminLogAnalyticsWorkspace struct {
+	Id                    string         `json:"id"`
+	LogAnalyticsWorkspace *AzureResource `json:"logAnalyticsWorkspace"`
+}
+
+// Validate ensures that the UpdateGroupRequest is valid.
+// It returns the body of the request, holding only the properties which are set,
+// or nil when none is, as there is nothing to update.
+func (g *UpdateGroupRequest) Validate() interface{} {
+	body := map[string]interface{}{}
+
+	if g.Name != "" {
+		body["name"] = g.Name
+	}
+	if g.DefaultDatasetStorageFormat != "" {
+		body["defaultDatasetStorageFormat"] = g.DefaultDatasetStorageFormat
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+	return body
+}
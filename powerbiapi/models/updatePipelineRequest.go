@@ -1,11 +1,5 @@
 package models
 
-// UpdatePipelineRequest represents a request to update a Power BI pipeline (workspace).
-type UpdatePipelineRequest struct {
-	DisplayName string `json:"displayName"` // The pipeline (workspace) name
-	Description string `json:"description"` //The default dataset storage format in the pipeline
-}
-
 // UpdatePipelineRequestName represents a request to update a Power BI pipeline (workspace) name.
 // This is a helper struct to allow the UpdatePipelineRequest to be validated.
 type UpdatePipelineRequestName struct {
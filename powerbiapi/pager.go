@@ -0,0 +1,170 @@
+package powerbiapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// DefaultPageSize is the number of items requested per page when the pager options do not set one.
+const DefaultPageSize = 1000
+
+// Pager iterates over the pages of a list operation. Call NextPage while More returns true:
+//
+//	pager := client.NewGroupsPager(nil)
+//	for pager.More() {
+//		page, err := pager.NextPage(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		for _, group := range page.Value {
+//			...
+//		}
+//	}
+type Pager[T any] struct {
+	more  bool
+	fetch func(ctx context.Context) (*T, bool, error)
+}
+
+// newPager returns a Pager calling fetch with the $top and $skip of each page.
+// fetch returns the page and its number of items, a short page ends the iteration.
+func newPager[T any](pageSize int, fetch func(ctx context.Context, top int, skip int) (*T, int, error)) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	skip := 0
+	return &Pager[T]{more: true, fetch: func(ctx context.Context) (*T, bool, error) {
+		page, count, err := fetch(ctx, pageSize, skip)
+		if err != nil {
+			return nil, false, err
+		}
+
+		skip += count
+		return page, count >= pageSize, nil
+	}}
+}
+
+// newContinuationPager returns a Pager calling fetch with the continuation token of each page,
+// empty for the first one. fetch returns the page and the token of the next page, an empty token ends the iteration.
+func newContinuationPager[T any](fetch func(ctx context.Context, continuationToken string) (*T, string, error)) *Pager[T] {
+	token := ""
+	return &Pager[T]{more: true, fetch: func(ctx context.Context) (*T, bool, error) {
+		page, next, err := fetch(ctx, token)
+		if err != nil {
+			return nil, false, err
+		}
+
+		token = next
+		return page, next != "", nil
+	}}
+}
+
+// More tells whether there are more pages to fetch.
+func (p *Pager[T]) More() bool {
+	return p.more
+}
+
+// NextPage fetches the next page. A failed page can be fetched again by calling NextPage again.
+func (p *Pager[T]) NextPage(ctx context.Context) (*T, error) {
+	if !p.more {
+		return nil, errors.New("no more pages")
+	}
+
+	page, more, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.more = more
+
+	return page, nil
+}
+
+// GroupsPagerOptions are the options of NewGroupsPager.
+type GroupsPagerOptions struct {
+	Filter   string // An OData filter, such as "name eq 'Sales'"
+	PageSize int    // The number of workspaces per page, DefaultPageSize when zero
+}
+
+// NewGroupsPager returns a Pager over the workspaces the user has access to. Options may be nil.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/groups/get-groups
+func (c *Client) NewGroupsPager(options *GroupsPagerOptions) *Pager[models.Groups] {
+	if options == nil {
+		options = &GroupsPagerOptions{}
+	}
+
+	return newPager(options.PageSize, func(ctx context.Context, top int, skip int) (*models.Groups, int, error) {
+		groups, err := c.GetGroups(ctx, options.Filter, top, skip)
+		if err != nil {
+			return nil, 0, err
+		}
+		return groups, len(groups.Value), nil
+	})
+}
+
+// AdminGroupsPagerOptions are the options of NewAdminGroupsPager.
+type AdminGroupsPagerOptions struct {
+	Filter   string // An OData filter, such as "state eq 'Deleted'"
+	PageSize int    // The number of workspaces per page, DefaultPageSize when zero
+}
+
+// NewAdminGroupsPager returns a Pager over the workspaces of the organization, listed by the admin API. Options may be nil.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/admin/groups-get-groups-as-admin
+func (c *Client) NewAdminGroupsPager(options *AdminGroupsPagerOptions) *Pager[models.Groups] {
+	if options == nil {
+		options = &AdminGroupsPagerOptions{}
+	}
+
+	return newPager(options.PageSize, func(ctx context.Context, top int, skip int) (*models.Groups, int, error) {
+		groups, err := c.GetGroupsAsAdmin(ctx, options.Filter, top, skip)
+		if err != nil {
+			return nil, 0, err
+		}
+		return groups, len(groups.Value), nil
+	})
+}
+
+// GroupUsersPagerOptions are the options of NewGroupUsersPager.
+type GroupUsersPagerOptions struct {
+	PageSize int // The number of users per page, DefaultPageSize when zero
+}
+
+// NewGroupUsersPager returns a Pager over the users of a workspace. Options may be nil.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/groups/get-group-users
+func (c *Client) NewGroupUsersPager(groupId string, options *GroupUsersPagerOptions) *Pager[models.GroupUsers] {
+	if options == nil {
+		options = &GroupUsersPagerOptions{}
+	}
+
+	return newPager(options.PageSize, func(ctx context.Context, top int, skip int) (*models.GroupUsers, int, error) {
+		users, err := c.getGroupUsersPage(ctx, groupId, top, skip)
+		if err != nil {
+			return nil, 0, err
+		}
+		return users, len(users.Value), nil
+	})
+}
+
+// ItemsPagerOptions are the options of NewItemsPager.
+type ItemsPagerOptions struct {
+	Type string // The type of the items to list, such as Datamart, all the items when empty
+}
+
+// NewItemsPager returns a Pager over the items of a workspace, listed by the Fabric API. Options may be nil.
+// The Fabric API sets the size of the pages.
+// https://learn.microsoft.com/en-us/rest/api/fabric/core/items/list-items
+func (c *Client) NewItemsPager(workspaceId string, options *ItemsPagerOptions) *Pager[models.Items] {
+	if options == nil {
+		options = &ItemsPagerOptions{}
+	}
+
+	return newContinuationPager(func(ctx context.Context, continuationToken string) (*models.Items, string, error) {
+		items, err := c.ListItems(ctx, workspaceId, options.Type, continuationToken)
+		if err != nil {
+			return nil, "", err
+		}
+		return items, items.ContinuationToken, nil
+	})
+}
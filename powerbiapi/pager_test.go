@@ -0,0 +1,115 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// TestGroupsPager is a unit test function that tests the workspaces are listed page by page, with the filter.
+func TestGroupsPager(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	for i := 0; i < 5; i++ {
+		server.PutGroup(fake.Group{Name: fmt.Sprintf("TF_PAGED_%d", i)})
+	}
+	server.PutGroup(fake.Group{Name: "Finance"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	pager := client.NewGroupsPager(&GroupsPagerOptions{Filter: "startswith(name,'TF_PAGED')", PageSize: 2})
+
+	var names []string
+	pages := 0
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		pages++
+		for _, group := range page.Value {
+			names = append(names, group.Name)
+		}
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []string{"TF_PAGED_0", "TF_PAGED_1", "TF_PAGED_2", "TF_PAGED_3", "TF_PAGED_4"}, names)
+
+	_, err = pager.NextPage(ctx)
+	assert.Error(t, err)
+}
+
+// TestGroupUsersPager is a unit test function that tests the users of a workspace are listed page by page.
+func TestGroupUsersPager(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	group, err := client.CreateGroup(ctx, "TF_PAGED_USERS")
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		server.PutGroupUser(group.Id, fake.GroupUser{
+			EmailAddress:         fmt.Sprintf("user%d@example.com", i),
+			GroupUserAccessRight: string(models.GroupUserAccessRightViewer),
+			Identifier:           fmt.Sprintf("user%d@example.com", i),
+			PrincipalType:        string(models.PrincipalTypeUser),
+		})
+	}
+
+	pager := client.NewGroupUsersPager(group.Id, &GroupUsersPagerOptions{PageSize: 2})
+
+	var users []models.GroupUser
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		users = append(users, page.Value...)
+	}
+
+	// The caller, added as admin when the workspace was created, and the three viewers.
+	assert.Len(t, users, 4)
+}
+
+// TestItemsPager is a unit test function that tests the items of a workspace are listed page by page,
+// following the continuation tokens of the Fabric API, and filtered by type.
+func TestItemsPager(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	server.SetItemsPageSize(2)
+	group := server.PutGroup(fake.Group{Name: "TF_PAGED_ITEMS"})
+	server.PutReport(group.Id, fake.Report{Name: "Sales"})
+	for i := 0; i < 5; i++ {
+		server.PutDatamart(group.Id, fake.Datamart{Name: fmt.Sprintf("TF_DATAMART_%d", i)})
+	}
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	pager := client.NewItemsPager(group.Id, &ItemsPagerOptions{Type: "Datamart"})
+
+	var names []string
+	pages := 0
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		pages++
+		for _, item := range page.Value {
+			assert.Equal(t, "Datamart", item.Type)
+			names = append(names, item.DisplayName)
+		}
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []string{"TF_DATAMART_0", "TF_DATAMART_1", "TF_DATAMART_2", "TF_DATAMART_3", "TF_DATAMART_4"}, names)
+}
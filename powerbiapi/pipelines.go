@@ -0,0 +1,190 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// GetPipeline returns the specified deployment pipeline.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/pipelines/get-pipeline
+func (c *Client) GetPipeline(ctx context.Context, pipelineId string) (*models.Pipeline, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/pipelines/{pipelineId}
+
+	var err error
+	pipeline := &models.Pipeline{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetPipelines: %w", err)
+	}
+
+	// The "expand" query parameter is used to include the stages in the response.
+	resp, err := client.SetResult(pipeline).SetQueryParam("$expand", "stages").
+		Get(fmt.Sprintf("/v1.0/myorg/pipelines/%s", pipelineId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipelines: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get pipelines: %w", newError(resp))
+	}
+
+	return pipeline, nil
+}
+
+// GetPipelines returns the deployment pipelines the user has access to, with their stages.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/pipelines/get-pipelines
+func (c *Client) GetPipelines(ctx context.Context) (*models.Pipelines, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/pipelines
+
+	var err error
+	pipelines := &models.Pipelines{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetPipelines: %w", err)
+	}
+
+	resp, err := client.SetResult(pipelines).SetQueryParam("$expand", "stages").
+		Get("/v1.0/myorg/pipelines")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipelines: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get pipelines: %w", newError(resp))
+	}
+
+	return pipelines, nil
+}
+
+// CreatePipeline returns the specified deployment pipeline.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/pipelines/create-pipeline
+func (c *Client) CreatePipeline(ctx context.Context, displayName string, description string) (*models.Pipeline, error) {
+	// POST https://api.powerbi.com/v1.0/myorg/pipelines
+
+	var err error
+	pipeline := &models.Pipeline{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for CreatePipeline: %w", err)
+	}
+
+	resp, err := client.SetResult(pipeline).
+		SetBody(&models.PipelineCreationRequest{DisplayName: displayName, Description: description}).
+		Post("/v1.0/myorg/pipelines")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pipeline: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to create pipeline: %w", newError(resp))
+	}
+
+	return pipeline, nil
+}
+
+// DeletePipeline returns the specified deployment pipeline.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/pipelines/create-pipeline
+func (c *Client) DeletePipeline(ctx context.Context, pipelineId string) error {
+	// POST https://api.powerbi.com/v1.0/myorg/pipelines
+
+	var err error
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare the request for DeletePipeline: %w", err)
+	}
+
+	resp, err := client.
+		Delete(fmt.Sprintf("/v1.0/myorg/pipelines/%s", pipelineId))
+	if err != nil {
+		return fmt.Errorf("failed to delete pipeline: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to delete pipeline: %w", newError(resp))
+	}
+
+	return nil
+}
+
+// UpdatePipeline returns the specified deployment pipeline.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/pipelines/create-pipeline
+func (c *Client) UpdatePipeline(ctx context.Context, pipelineId string, request models.UpdatePipelineRequest) (*models.Pipeline, error) {
+	// POST https://api.powerbi.com/v1.0/myorg/pipelines
+
+	var err error
+	pipeline := &models.Pipeline{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for DeletePipeline: %w", err)
+	}
+
+	resp, err := client.
+		SetResult(pipeline).
+		SetBody(&models.UpdatePipelineRequest{DisplayName: request.DisplayName, Description: request.Description}).
+		Patch(fmt.Sprintf("/v1.0/myorg/pipelines/%s", pipelineId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pipeline: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to update pipeline: %w", newError(resp))
+	}
+
+	return pipeline, nil
+}
+
+// UnassignWorkspace unassigns the workspace from the specified deployment pipeline stage.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/pipelines/unassign-workspace
+func (c *Client) UnassignWorkspace(ctx context.Context, pipelineId string, stageOrder int) error {
+	// POST https://api.powerbi.com/v1.0/myorg/pipelines/{pipelineId}/stages/{stageOrder}/unassignWorkspace
+
+	var err error
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare the request for UnassignWorkspace: %w", err)
+	}
+
+	resp, err := client.
+		Post(fmt.Sprintf("/v1.0/myorg/pipelines/%s/stages/%d/unassignWorkspace", pipelineId, stageOrder))
+	if err != nil {
+		return fmt.Errorf("failed to unassign workspace from pipeline: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to unassign workspace from pipeline: %w", newError(resp))
+	}
+
+	return nil
+}
+
+// This is commented out, to be moved in its own resource to respect the terraform provider philosophy
+//// AssignWorkspace assigns a workspace to a PowerBi Pipeline.
+//// https://learn.microsoft.com/en-us/rest/api/power-bi/pipelines/assign-workspace
+//func (c *Client) AssignWorkspace(ctx context.Context, pipelineId string, stageOrder int, workspaceId string) error {
+//	// POST https://api.powerbi.com/v1.0/myorg/pipelines/{pipelineId}/stages/{stageOrder}/assignWorkspace
+//
+//	var err error
+//	client, err := c.prepRequest(ctx)
+//	if err != nil {
+//		return fmt.Errorf("failed to prepare the request for AssignWorkspace: %w", err)
+//	}
+//
+//	resp, err := client.SetBody(&models.AssignWorkspaceRequest{WorkspaceId: workspaceId}).
+//		Post(fmt.Sprintf("/v1.0/myorg/pipelines/%s/stages/%d/assignWorkspace", pipelineId, stageOrder))
+//	if err != nil {
+//		return fmt.Errorf("failed to assign workspace to pipeline: %w", err)
+//	}
+//	if resp.IsError() {
+//		return fmt.Errorf("failed to assign workspace to pipeline: %w", newError(resp))
+//	}
+//
+//	return nil
+//}
@@ -1,13 +1,15 @@
 package powerbiapi
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"terraform-provider-powerbi/internal/powerbiapi/models"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
 )
 
 // TestGetPipeline is a unit test function that tests the GetPipeline function.
@@ -15,6 +17,7 @@ import (
 // The function verifies that the request URL, method, and response are correct.
 // It also checks if the returned pipeline object has the expected ID and name.
 func TestGetPipeline(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -44,11 +47,11 @@ func TestGetPipeline(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host := "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the GetGroup function
-	pipeline, err := client.GetPipeline("57eb01e2-2803-4d0d-ae65-8fd112ae5b7c")
+	pipeline, err := client.GetPipeline(ctx, "57eb01e2-2803-4d0d-ae65-8fd112ae5b7c")
 
 	// Check the result
 	assert.NoError(t, err)
@@ -61,6 +64,7 @@ func TestGetPipeline(t *testing.T) {
 // The function verifies that the request URL, method, and response are correct.
 // It also checks if the returned pipeline  object has the expected ID and name.
 func TestCreatePipeline(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -102,11 +106,11 @@ func TestCreatePipeline(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host := "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the CreatePipeline function
-	pipeline, err := client.CreatePipeline("test_pipeline", "test Pipeline")
+	pipeline, err := client.CreatePipeline(ctx, "test_pipeline", "test Pipeline")
 
 	// Check the result
 	assert.NoError(t, err)
@@ -118,6 +122,7 @@ func TestCreatePipeline(t *testing.T) {
 // It creates a test server, sends a mock request to the server, and checks the response.
 // The function verifies that the request URL, method, and response are correct.
 func TestDeletePipeline(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -135,11 +140,11 @@ func TestDeletePipeline(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host := "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	// Call the CreatePipeline function
-	err = client.DeletePipeline("70ab2a0e-77ec-43d1-a473-efb6058ba37d")
+	err = client.DeletePipeline(ctx, "70ab2a0e-77ec-43d1-a473-efb6058ba37d")
 
 	// Check the result
 	assert.NoError(t, err)
@@ -149,6 +154,7 @@ func TestDeletePipeline(t *testing.T) {
 // It creates a test server, sends a mock request to the server, and checks the response.
 // The function verifies that the request URL, method, and response are correct.
 func TestUpdatePipeline(t *testing.T) {
+	ctx := context.Background()
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check the request URL
@@ -190,12 +196,12 @@ func TestUpdatePipeline(t *testing.T) {
 	// Create a client with the test server URL
 	host := server.URL
 	//host := "https://api.powerbi.com"
-	client, err := NewClient(host)
+	client, err := newTestClient(host)
 	assert.NoError(t, err)
 
 	updatePipelineRequest := &models.UpdatePipelineRequest{DisplayName: "test_pipeline_rename", Description: "description_rename"}
 	// Call the CreatePipeline function
-	pipeline, err := client.UpdatePipeline("70ab2a0e-77ec-43d1-a473-efb6058ba37d", *updatePipelineRequest)
+	pipeline, err := client.UpdatePipeline(ctx, "70ab2a0e-77ec-43d1-a473-efb6058ba37d", *updatePipelineRequest)
 
 	// Check the result
 	assert.NoError(t, err)
@@ -203,6 +209,82 @@ func TestUpdatePipeline(t *testing.T) {
 	assert.Equal(t, "description_rename", pipeline.Description)
 }
 
+// TestGetPipelines is a unit test function that tests the GetPipelines function.
+// It creates a test server, sends a mock request to the server, and checks the response.
+// The function verifies that the stages are expanded and returned with the pipelines.
+func TestGetPipelines(t *testing.T) {
+	ctx := context.Background()
+	// Create a test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check the request URL
+		assert.Equal(t, "/v1.0/myorg/pipelines", r.URL.Path)
+		assert.Equal(t, "stages", r.URL.Query().Get("$expand"))
+
+		// Check the request method
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		// Send a mock response
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{
+			"@odata.context":"http://wabi-france-central-a-primary-redirect.analysis.windows.net/v1.0/myorg/$metadata#pipelines",
+			"value":[
+			  {
+				"id":"57eb01e2-2803-4d0d-ae65-8fd112ae5b7c","displayName":"wksPipeline","stages":[
+				  {"order":0,"workspaceId":"6ac9aad1-88c9-47d1-baa2-6c4d469fe7d4","workspaceName":"wks_DEV"},
+				  {"order":1},{"order":2}
+				]
+			  }
+			]
+		  }
+		  `)
+	}))
+	defer server.Close()
+
+	// Create a client with the test server URL
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	// Call the GetPipelines function
+	pipelines, err := client.GetPipelines(ctx)
+
+	// Check the result
+	assert.NoError(t, err)
+	if assert.Len(t, pipelines.Value, 1) {
+		assert.Equal(t, "wksPipeline", pipelines.Value[0].DisplayName)
+		assert.Len(t, pipelines.Value[0].Stages, 3)
+		assert.Equal(t, "6ac9aad1-88c9-47d1-baa2-6c4d469fe7d4", pipelines.Value[0].Stages[0].WorkspaceId)
+	}
+}
+
+// TestUnassignWorkspace is a unit test function that tests the UnassignWorkspace function.
+// It creates a test server, sends a mock request to the server, and checks the request URL and method.
+func TestUnassignWorkspace(t *testing.T) {
+	ctx := context.Background()
+	// Create a test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check the request URL
+		assert.Equal(t, "/v1.0/myorg/pipelines/57eb01e2-2803-4d0d-ae65-8fd112ae5b7c/stages/1/unassignWorkspace", r.URL.Path)
+
+		// Check the request method
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		// Send a mock response
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Create a client with the test server URL
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	// Call the UnassignWorkspace function
+	err = client.UnassignWorkspace(ctx, "57eb01e2-2803-4d0d-ae65-8fd112ae5b7c", 1)
+
+	// Check the result
+	assert.NoError(t, err)
+}
+
 //// TestAssignWorkspace is a unit test function that tests the AssignToWorkspace function.
 //// It creates a test server, sends a mock request to the server, and checks the response.
 //// The function verifies that the request URL, method, and response are correct.
@@ -0,0 +1,32 @@
+package powerbiapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// GetReportsInGroup retrieves the reports of a group.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/reports/get-reports-in-group
+func (c *Client) GetReportsInGroup(ctx context.Context, groupId string) (*models.Reports, error) {
+	// GET https://api.powerbi.com/v1.0/myorg/groups/{groupId}/reports
+
+	reports := &models.Reports{}
+
+	client, err := c.prepRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetReportsInGroup: %w", err)
+	}
+
+	resp, err := client.SetResult(reports).Get(fmt.Sprintf("/v1.0/myorg/groups/%s/reports", groupId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group reports: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get group reports: %w", newError(resp))
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,46 @@
+package powerbiapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+)
+
+// TestGetReportsInGroup is a unit test function that tests the GetReportsInGroup method of the Client struct.
+// It verifies that only the reports of the requested group are listed.
+func TestGetReportsInGroup(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "SALES"})
+	other := server.PutGroup(fake.Group{Name: "FINANCE"})
+	report := server.PutReport(group.Id, fake.Report{Name: "Sales", ReportType: "PowerBIReport"})
+	server.PutReport(other.Id, fake.Report{Name: "Budget"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	reports, err := client.GetReportsInGroup(ctx, group.Id)
+
+	assert.NoError(t, err)
+	assert.Len(t, reports.Value, 1)
+	assert.Equal(t, report.Id, reports.Value[0].Id)
+	assert.Equal(t, "Sales", reports.Value[0].Name)
+	assert.Equal(t, "PowerBIReport", reports.Value[0].ReportType)
+}
+
+// TestGetReportsInGroup_NotFound is a unit test function that verifies that listing the reports
+// of a missing group fails with a not found error.
+func TestGetReportsInGroup_NotFound(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	_, err = client.GetReportsInGroup(ctx, "f089354e-8366-4e18-aea3-4cb4a3a50b48")
+
+	assert.True(t, IsNotFound(err))
+}
@@ -0,0 +1,123 @@
+package powerbiapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// ErrFabricUnavailable is returned by the operations relying on the Fabric API when the client has no FabricURL.
+var ErrFabricUnavailable = errors.New("the Fabric API is not available, the client has no Fabric URL")
+
+// GetWorkspace returns the specified workspace from the Fabric API.
+// https://learn.microsoft.com/en-us/rest/api/fabric/core/workspaces/get-workspace
+func (c *Client) GetWorkspace(ctx context.Context, workspaceId string) (*models.WorkspaceInfo, error) {
+	// GET https://api.fabric.microsoft.com/v1/workspaces/{workspaceId}
+
+	workspace := &models.WorkspaceInfo{}
+
+	client, err := c.prepFabricRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for GetWorkspace: %w", err)
+	}
+
+	resp, err := client.SetResult(workspace).Get(fmt.Sprintf("%s/v1/workspaces/%s", c.FabricURL, workspaceId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get workspace: %w", newError(resp))
+	}
+
+	return workspace, nil
+}
+
+// UpdateWorkspace updates the specified workspace through the Fabric API.
+// Unlike UpdateGroup, it sends the empty properties, so the request must hold the current display name.
+// https://learn.microsoft.com/en-us/rest/api/fabric/core/workspaces/update-workspace
+func (c *Client) UpdateWorkspace(ctx context.Context, workspaceId string, request models.UpdateWorkspaceRequest) (*models.Workspace, error) {
+	// PATCH https://api.fabric.microsoft.com/v1/workspaces/{workspaceId}
+
+	workspace := &models.Workspace{}
+
+	client, err := c.prepFabricRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for UpdateWorkspace: %w", err)
+	}
+
+	resp, err := client.SetResult(workspace).SetBody(request).
+		Patch(fmt.Sprintf("%s/v1/workspaces/%s", c.FabricURL, workspaceId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update workspace: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to update workspace: %w", newError(resp))
+	}
+
+	return workspace, nil
+}
+
+// ListItems returns a page of the items of the specified workspace from the Fabric API, filtered by type
+// when itemType is not empty. The first page is returned for an empty continuation token.
+// Use NewItemsPager to iterate over all the pages.
+// https://learn.microsoft.com/en-us/rest/api/fabric/core/items/list-items
+func (c *Client) ListItems(ctx context.Context, workspaceId string, itemType string, continuationToken string) (*models.Items, error) {
+	// GET https://api.fabric.microsoft.com/v1/workspaces/{workspaceId}/items
+
+	items := &models.Items{}
+
+	client, err := c.prepFabricRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the request for ListItems: %w", err)
+	}
+
+	if itemType != "" {
+		client.SetQueryParam("type", itemType)
+	}
+	if continuationToken != "" {
+		client.SetQueryParam("continuationToken", continuationToken)
+	}
+
+	resp, err := client.SetResult(items).Get(fmt.Sprintf("%s/v1/workspaces/%s/items", c.FabricURL, workspaceId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace items: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to list workspace items: %w", newError(resp))
+	}
+
+	return items, nil
+}
+
+// GetGroupDescription returns the description of a group.
+// The Power BI API does not expose it, it is read from the Fabric API, and ErrFabricUnavailable
+// is returned when the client cannot use it.
+func (c *Client) GetGroupDescription(ctx context.Context, groupId string) (string, error) {
+	workspace, err := c.GetWorkspace(ctx, groupId)
+	if err != nil {
+		return "", err
+	}
+
+	return workspace.Description, nil
+}
+
+// UpdateGroupDescription sets the description of a group, an empty description clearing it.
+// The Power BI API cannot set it, it is set through the Fabric API, and ErrFabricUnavailable
+// is returned when the client cannot use it.
+func (c *Client) UpdateGroupDescription(ctx context.Context, groupId string, description string) error {
+	workspace, err := c.GetWorkspace(ctx, groupId)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.UpdateWorkspace(ctx, groupId, models.UpdateWorkspaceRequest{
+		DisplayName: workspace.DisplayName,
+		Description: description,
+	})
+	return err
+}
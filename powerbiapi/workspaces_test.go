@@ -0,0 +1,102 @@
+package powerbiapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/fake"
+	"github.com/WeAreRetail/terraform-provider-powerbi/powerbiapi/models"
+)
+
+// TestGroupDescription is a unit test function that tests the UpdateGroupDescription and GetGroupDescription
+// methods of the Client struct. The description is set through the Fabric API and keeps the group name.
+func TestGroupDescription(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "SALES"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.UpdateGroupDescription(ctx, group.Id, "Sales reports"))
+
+	description, err := client.GetGroupDescription(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Sales reports", description)
+
+	workspace, err := client.GetWorkspace(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "SALES", workspace.DisplayName)
+
+	assert.NoError(t, client.UpdateGroupDescription(ctx, group.Id, ""))
+
+	description, err = client.GetGroupDescription(ctx, group.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, description)
+}
+
+// TestGetWorkspace_NotFound is a unit test function that verifies that the errors of the Fabric API,
+// which have their own payload, are returned as typed errors.
+func TestGetWorkspace_NotFound(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	_, err = client.GetWorkspace(ctx, "f089354e-8366-4e18-aea3-4cb4a3a50b48")
+
+	assert.True(t, IsNotFound(err))
+	assert.True(t, HasErrorCode(err, "EntityNotFound"))
+}
+
+// TestGroupDescription_FabricUnavailable is a unit test function that verifies that the descriptions
+// cannot be read or set without a Fabric URL, and that no request is sent.
+func TestGroupDescription_FabricUnavailable(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "SALES"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+	client.FabricURL = ""
+
+	_, err = client.GetGroupDescription(ctx, group.Id)
+	assert.ErrorIs(t, err, ErrFabricUnavailable)
+
+	err = client.UpdateGroupDescription(ctx, group.Id, "Sales reports")
+	assert.ErrorIs(t, err, ErrFabricUnavailable)
+
+	assert.Empty(t, server.Requests())
+}
+
+// TestNewClient_FabricURL is a unit test function that verifies that the Fabric API is only used
+// with the default Power BI host.
+func TestNewClient_FabricURL(t *testing.T) {
+	client, err := NewClient("")
+	assert.NoError(t, err)
+	assert.Equal(t, FabricBaseURL, client.FabricURL)
+
+	client, err = NewClient("https://api.powerbigov.us")
+	assert.NoError(t, err)
+	assert.Empty(t, client.FabricURL)
+}
+
+// TestUpdateWorkspace_Conflict is a unit test function that verifies that renaming a workspace through
+// the Fabric API like another one fails with a conflict.
+func TestUpdateWorkspace_Conflict(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewTestServer(t)
+	group := server.PutGroup(fake.Group{Name: "SALES"})
+	server.PutGroup(fake.Group{Name: "FINANCE"})
+
+	client, err := newTestClient(server.URL)
+	assert.NoError(t, err)
+
+	_, err = client.UpdateWorkspace(ctx, group.Id, models.UpdateWorkspaceRequest{DisplayName: "FINANCE"})
+
+	assert.True(t, HasStatusCode(err, http.StatusConflict))
+}
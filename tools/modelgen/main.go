@@ -0,0 +1,312 @@
+// Command modelgen generates the Go models of the Power BI, Fabric and Microsoft Graph REST APIs from the definitions of a swagger 2.0 specification.
+//
+// Every object definition becomes a struct, and every enum, named by its x-ms-enum extension, becomes a string type
+// with one constant per value. It is run by go generate in powerbiapi/models:
+//
+//	go run ../../tools/modelgen -spec ../internal/openapi/swagger.json -out models_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Spec is the part of a swagger 2.0 specification used by the generator.
+type Spec struct {
+	Info struct {
+		Title string `json:"title"`
+	} `json:"info"`
+	Definitions map[string]*Schema `json:"definitions"`
+}
+
+// Schema is a swagger 2.0 schema object.
+type Schema struct {
+	Ref         string             `json:"$ref"`
+	Type        string             `json:"type"`
+	Format      string             `json:"format"`
+	Description string             `json:"description"`
+	Required    []string           `json:"required"`
+	Properties  map[string]*Schema `json:"properties"`
+	Items       *Schema            `json:"items"`
+	Enum        []string           `json:"enum"`
+	XMsEnum     *XMsEnum           `json:"x-ms-enum"`
+}
+
+// XMsEnum is the x-ms-enum extension, naming an enum and describing its values.
+type XMsEnum struct {
+	Name   string `json:"name"`
+	Values []struct {
+		Value       string `json:"value"`
+		Description string `json:"description"`
+	} `json:"values"`
+}
+
+// enum is an enum type to generate.
+type enum struct {
+	name        string
+	description string
+	schema      *Schema
+}
+
+func main() {
+	specPath := flag.String("spec", "", "path of the swagger specification")
+	outPath := flag.String("out", "models_gen.go", "path of the generated file")
+	pkg := flag.String("package", "models", "package of the generated file")
+	flag.Parse()
+
+	content, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatalf("failed to read the specification: %v", err)
+	}
+
+	source, err := Generate(content, *pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*outPath, source, 0o644); err != nil {
+		log.Fatalf("failed to write the models: %v", err)
+	}
+}
+
+// Generate returns the formatted Go source of the models described by the specification.
+func Generate(content []byte, pkg string) ([]byte, error) {
+	var spec Spec
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse the specification: %v", err)
+	}
+
+	enums := map[string]enum{}
+	var structs []string
+
+	for name, schema := range spec.Definitions {
+		switch {
+		case len(schema.Enum) > 0:
+			enums[enumName(name, schema)] = enum{name: enumName(name, schema), description: schema.Description, schema: schema}
+		case schema.Type == "object" || len(schema.Properties) > 0:
+			structs = append(structs, name)
+			// Enums declared inline in a property are named by their x-ms-enum extension.
+			for _, property := range schema.Properties {
+				if len(property.Enum) > 0 && property.XMsEnum != nil {
+					if _, ok := enums[property.XMsEnum.Name]; !ok {
+						enums[property.XMsEnum.Name] = enum{name: property.XMsEnum.Name, description: property.Description, schema: property}
+					}
+				}
+			}
+		default:
+			return nil, fmt.Errorf("definition %s is neither an object nor an enum", name)
+		}
+	}
+
+	var buf bytes.Buffer
+	// The snapshots are titled after the SDK client, "Power BI Client", "Fabric Client" or "Microsoft Graph Client".
+	api := strings.TrimSuffix(spec.Info.Title, " Client")
+	if api == "" {
+		api = "Power BI"
+	}
+	fmt.Fprintf(&buf, "// Code generated by modelgen from the %s REST API specification. DO NOT EDIT.\n\n", api)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	if usesTime(spec.Definitions) {
+		fmt.Fprintf(&buf, "import \"time\"\n\n")
+	}
+
+	sort.Strings(structs)
+	for _, name := range structs {
+		if err := writeStruct(&buf, name, spec.Definitions[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	enumNames := make([]string, 0, len(enums))
+	for name := range enums {
+		enumNames = append(enumNames, name)
+	}
+	sort.Strings(enumNames)
+	for _, name := range enumNames {
+		writeEnum(&buf, enums[name])
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the models: %v\n%s", err, buf.String())
+	}
+
+	return source, nil
+}
+
+// writeStruct writes the struct of an object definition, with its properties sorted by name.
+func writeStruct(buf *bytes.Buffer, name string, schema *Schema) error {
+	writeComment(buf, name, schema.Description)
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	for _, property := range properties {
+		goType, err := typeOf(schema.Properties[property])
+		if err != nil {
+			return fmt.Errorf("definition %s, property %s: %v", name, property, err)
+		}
+
+		fmt.Fprintf(buf, "\t%s %s `json:\"%s\"`", fieldName(property), goType, property)
+		if description := schema.Properties[property].Description; description != "" {
+			fmt.Fprintf(buf, " // %s", sentence(description))
+		}
+		fmt.Fprintln(buf)
+	}
+
+	fmt.Fprintf(buf, "}\n\n")
+
+	return nil
+}
+
+// writeEnum writes the type of an enum and its constants, sorted by value.
+func writeEnum(buf *bytes.Buffer, e enum) {
+	writeComment(buf, e.name, e.description)
+	fmt.Fprintf(buf, "type %s string\n\n", e.name)
+
+	descriptions := map[string]string{}
+	if e.schema.XMsEnum != nil {
+		for _, value := range e.schema.XMsEnum.Values {
+			descriptions[value.Value] = value.Description
+		}
+	}
+
+	values := append([]string(nil), e.schema.Enum...)
+	sort.Strings(values)
+
+	fmt.Fprintf(buf, "const (\n")
+	for _, value := range values {
+		fmt.Fprintf(buf, "\t%s%s %s = %q", e.name, fieldName(value), e.name, value)
+		if description := descriptions[value]; description != "" {
+			fmt.Fprintf(buf, " // %s", sentence(description))
+		}
+		fmt.Fprintln(buf)
+	}
+	fmt.Fprintf(buf, ")\n\n")
+}
+
+// writeComment writes the doc comment of a type, turning "A Power BI group" into "Group is a Power BI group.".
+func writeComment(buf *bytes.Buffer, name string, description string) {
+	if description == "" {
+		fmt.Fprintf(buf, "// %s is a Power BI REST API model.\n", name)
+		return
+	}
+
+	for _, article := range []string{"A ", "An ", "The "} {
+		if strings.HasPrefix(description, article) {
+			fmt.Fprintf(buf, "// %s is %s\n", name, sentence(strings.ToLower(article[:1])+description[1:]))
+			return
+		}
+	}
+
+	fmt.Fprintf(buf, "// %s - %s\n", name, sentence(description))
+}
+
+// typeOf returns the Go type of a property schema.
+func typeOf(schema *Schema) (string, error) {
+	if schema.Ref != "" {
+		return strings.TrimPrefix(schema.Ref, "#/definitions/"), nil
+	}
+
+	switch schema.Type {
+	case "string":
+		switch {
+		case len(schema.Enum) > 0 && schema.XMsEnum != nil:
+			return schema.XMsEnum.Name, nil
+		case schema.Format == "date-time":
+			return "time.Time", nil
+		default:
+			return "string", nil
+		}
+	case "integer":
+		if schema.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if schema.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		item, err := typeOf(schema.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		return "map[string]interface{}", nil
+	default:
+		return "", fmt.Errorf("unsupported type %q", schema.Type)
+	}
+}
+
+// usesTime tells whether a definition has a date-time property.
+func usesTime(definitions map[string]*Schema) bool {
+	for _, schema := range definitions {
+		for _, property := range schema.Properties {
+			if property.Type == "string" && property.Format == "date-time" {
+				return true
+			}
+			if property.Items != nil && property.Items.Type == "string" && property.Items.Format == "date-time" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// enumName returns the name of an enum definition, preferring its x-ms-enum name.
+func enumName(name string, schema *Schema) string {
+	if schema.XMsEnum != nil && schema.XMsEnum.Name != "" {
+		return schema.XMsEnum.Name
+	}
+	return name
+}
+
+// fieldName returns the Go name of a JSON property: "@odata.context" becomes ODataContext and "workspaceId" WorkspaceId.
+func fieldName(property string) string {
+	if strings.HasPrefix(property, "@odata.") {
+		return "OData" + fieldName(strings.TrimPrefix(property, "@odata."))
+	}
+
+	var name strings.Builder
+	upper := true
+	for _, r := range property {
+		if r == '.' || r == '_' || r == '-' || r == ' ' {
+			upper = true
+			continue
+		}
+		if upper {
+			name.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		} else {
+			name.WriteRune(r)
+		}
+	}
+
+	return name.String()
+}
+
+// sentence ends a description with a period.
+func sentence(description string) string {
+	description = strings.TrimSpace(description)
+	if strings.HasSuffix(description, ".") {
+		return description
+	}
+	return description + "."
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerate_UpToDate is a unit test function that checks the checked-in models match the specification snapshots.
+func TestGenerate_UpToDate(t *testing.T) {
+	outputs := map[string]string{
+		"swagger.json": "models_gen.go",
+		"fabric.json":  "fabric_gen.go",
+		"graph.json":   "graph_gen.go",
+	}
+
+	for specFile, outFile := range outputs {
+		t.Run(outFile, func(t *testing.T) {
+			spec, err := os.ReadFile("../../powerbiapi/internal/openapi/" + specFile)
+			assert.NoError(t, err)
+
+			expected, err := os.ReadFile("../../powerbiapi/models/" + outFile)
+			assert.NoError(t, err)
+
+			generated, err := Generate(spec, "models")
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), string(generated), outFile+" is out of date, run go generate in powerbiapi/models")
+		})
+	}
+}
+
+// TestGenerate is a unit test function that tests the generation of structs and enums.
+func TestGenerate(t *testing.T) {
+	spec := []byte(`{
+  "definitions": {
+    "Item": {
+      "description": "An item",
+      "type": "object",
+      "properties": {
+        "@odata.count": {"type": "integer"},
+        "createdAt": {"type": "string", "format": "date-time"},
+        "kind": {"type": "string", "enum": ["Big", "Small"], "x-ms-enum": {"name": "ItemKind"}},
+        "tags": {"type": "array", "items": {"type": "string"}},
+        "owner": {"$ref": "#/definitions/Owner"}
+      }
+    },
+    "Owner": {"type": "object", "properties": {"id": {"type": "string"}}}
+  }
+}`)
+
+	generated, err := Generate(spec, "models")
+	assert.NoError(t, err)
+
+	source := string(generated)
+	assert.Contains(t, source, `import "time"`)
+	assert.Contains(t, source, "// Item is an item.\ntype Item struct {")
+	assert.Regexp(t, "ODataCount +int +`json:\"@odata.count\"`", source)
+	assert.Regexp(t, "CreatedAt +time.Time", source)
+	assert.Regexp(t, "Kind +ItemKind", source)
+	assert.Regexp(t, "Owner +Owner", source)
+	assert.Regexp(t, `Tags +\[\]string`, source)
+	assert.Regexp(t, `ItemKindBig +ItemKind = "Big"`, source)
+}
+
+// TestGenerate_UnsupportedDefinition is a unit test function that tests a definition which is neither an object nor an enum is rejected.
+func TestGenerate_UnsupportedDefinition(t *testing.T) {
+	_, err := Generate([]byte(`{"definitions": {"Id": {"type": "string"}}}`), "models")
+	assert.Error(t, err)
+}